@@ -0,0 +1,22 @@
+package main
+
+import "time"
+
+func send(ch chan bool) {
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- true
+	}()
+}
+
+func main() {
+	ch := make(chan bool)
+	send(ch)
+	a := <-ch && true
+	send(ch)
+	b := <-ch || false
+	println(a, b)
+}
+
+// Output:
+// true true
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type T struct{ N int }
+
+type U struct{ N int }
+
+type V struct{ M string }
+
+func inc(u *U) { u.N++ }
+
+func main() {
+	t := &T{1}
+	var p interface{} = t
+	var u *U
+	u = p
+	u.N = 5
+	fmt.Println(t.N, u == (*U)(t))
+
+	var f interface{} = func(a int) int { return a * 2 }
+	var g func(int) int
+	g = f
+	fmt.Println(g(3))
+
+	var a interface{} = []interface{}{1, "2", 3.0}
+	var arr [3]int
+	arr = a
+	fmt.Println(arr)
+
+	defer func() {
+		fmt.Println(strings.HasSuffix(fmt.Sprint(recover()), "pointers only convert in place, to pointers to identical underlying types"))
+	}()
+	var v *V
+	v = p
+	fmt.Println(v)
+}
+
+// Output:
+// 5 true
+// 6
+// [1 2 3]
+// true
@@ -0,0 +1,53 @@
+package main
+
+import "fmt"
+
+type T struct {
+	N int
+	S string
+}
+
+type Stringer interface{ String() string }
+
+type Num string
+
+func (n Num) String() string { return string(n) }
+
+func ret(v interface{}) (int, string) { return v, v }
+
+func str(s Stringer) string { return s }
+
+func main() {
+	var x interface{} = "42"
+	var y interface{} = 7
+
+	fmt.Println(ret(x))
+	fmt.Println(str(Num("12")))
+
+	ch := make(chan int, 2)
+	ch <- x
+	ch <- 1.0
+	fmt.Println(<-ch, <-ch)
+
+	m := map[int]string{1: "one", 42: "answer"}
+	fmt.Println(m[x], m["1"])
+	m["2"] = "two"
+	delete(m, y)
+	fmt.Println(len(m), m[2])
+
+	fmt.Println(T{N: x, S: y}, T{x, y})
+	fmt.Println([]int{x, y, "3"}, map[string]int{"a": x, 5: y})
+
+	var n int = x
+	fmt.Println(n)
+}
+
+// Output:
+// 42 42
+// 12
+// 42 1
+// answer one
+// 3 two
+// {42 7} {42 7}
+// [42 7 3] map[5:7 a:42]
+// 42
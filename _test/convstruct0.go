@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type Base struct {
+	ID      int       `yaegi:"id"`
+	Created time.Time `json:"created"`
+}
+
+type T struct {
+	Base
+	name  string
+	Count int    `json:"count,omitempty"`
+	Skip  string `json:"-"`
+	Pos   [2]int
+}
+
+func main() {
+	var m interface{} = map[string]interface{}{"id": "7", "name": "x", "count": 2.0, "Skip": "s", "created": "2022-01-02T03:04:05Z", "pos": []interface{}{1, "2", 3}}
+	var t T
+	t = m
+	fmt.Println(t.ID, t.name, t.Count, t.Skip == "", t.Created.Year(), t.Pos)
+	var back map[string]interface{}
+	back = t
+	fmt.Println(len(back), back["id"], back["name"], back["count"], back["created"].(time.Time).Month(), back["Pos"])
+	t.Count = 0
+	back = t
+	_, ok := back["count"]
+	fmt.Println(ok)
+}
+
+// Output:
+// 7 x 2 true 2022 [1 2]
+// 5 7 x 2 January [1 2]
+// false
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type Config struct {
+	Timeout time.Duration `json:"timeout"`
+	Start   time.Time     `json:"start"`
+}
+
+func main() {
+	var d time.Duration = "1m30s"
+	fmt.Println(d, d.Seconds())
+
+	var raw interface{} = "250ms"
+	d = raw
+	fmt.Println(d)
+
+	var s string
+	s = d
+	fmt.Println(s)
+
+	var t time.Time = "2022-01-02T03:04:05Z"
+	fmt.Println(t.Year(), t.Month())
+
+	var secs interface{} = 1700000000
+	t = secs
+	fmt.Println(t)
+
+	var f float64
+	var n int64
+	f = t
+	n = t
+	s = t
+	fmt.Println(f, n, s)
+
+	var m interface{} = map[string]interface{}{"timeout": "2s", "start": 86400}
+	var cfg Config
+	cfg = m
+	fmt.Println(cfg.Timeout, cfg.Start)
+
+	fmt.Println(d == "250ms", time.Duration(5))
+}
+
+// Output:
+// 1m30s 90
+// 250ms
+// 250ms
+// 2022 January
+// 2023-11-14 22:13:20 +0000 UTC
+// 1.7e+09 1700000000 2023-11-14T22:13:20Z
+// 2s 1970-01-02 00:00:00 +0000 UTC
+// true 5ns
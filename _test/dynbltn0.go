@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+func main() {
+	var m interface{} = map[string]interface{}{"a": 1, "b": []interface{}{1, "x"}}
+	var s interface{} = "héllo"
+	var l interface{} = []interface{}{1, 2}
+	var a interface{} = &[3]int{}
+	var z interface{}
+	fmt.Println(len(m), len(s), len(l), len(a), cap(a), len(z))
+
+	l = append(l, 3, "y")
+	l = append(l, []int{4, 5}...)
+	z = append(z, 1)
+	fmt.Println(l, z)
+
+	var b interface{} = []byte("a")
+	b = append(b, "bc"...)
+	fmt.Println(string(b.([]byte)))
+
+	delete(m, "a")
+	fmt.Println(m)
+}
+
+// Output:
+// 2 6 2 3 3 0
+// [1 2 3 y 4 5] [1]
+// abc
+// map[b:[1 x]]
@@ -0,0 +1,56 @@
+package main
+
+import "fmt"
+
+type T struct {
+	Name string
+	age  int
+}
+
+func main() {
+	var l interface{} = []interface{}{"a", 2}
+	for i, v := range l {
+		fmt.Println(i, v)
+	}
+
+	var s interface{} = "hé!"
+	for i, r := range s {
+		fmt.Println(i, string(r.(rune)))
+	}
+
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+	close(c)
+	var ch interface{} = c
+	for v := range ch {
+		fmt.Println("recv", v)
+	}
+
+	var t interface{} = &T{"bob", 3}
+	for k, v := range t {
+		fmt.Println(k, v)
+	}
+
+	var m interface{} = map[string]int{"k": 1}
+	for k := range m {
+		fmt.Println(k)
+	}
+
+	var z interface{}
+	for range z {
+		fmt.Println("nil")
+	}
+}
+
+// Output:
+// 0 a
+// 1 2
+// 0 h
+// 1 é
+// 3 !
+// recv 1
+// recv 2
+// Name bob
+// age 3
+// k
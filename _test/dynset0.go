@@ -0,0 +1,40 @@
+package main
+
+import "fmt"
+
+func main() {
+	var cfg interface{} = map[string]interface{}{"timeout": 10, "db": map[string]interface{}{"port": 1}}
+	cfg.timeout = 30
+	cfg.db.port = "5432"
+	cfg["retries"] = 3
+	cfg.timeout += 5
+	cfg.retries++
+	cfg.hits--
+	fmt.Println(cfg.timeout, cfg.db.port, cfg.retries, cfg.hits)
+
+	var counts interface{} = map[string]int{}
+	counts.a = "7"
+	counts["a"] += 2
+	counts.b++
+	fmt.Println(counts)
+
+	var m interface{} = map[int]string{}
+	m["1"] = 2
+	m[1] += "3"
+	fmt.Println(m)
+
+	cfg.a, cfg.b = 1, "x"
+	cfg.a, cfg.b = cfg.b, cfg.a
+	fmt.Println(cfg.a, cfg.b)
+
+	delete(cfg, "db")
+	delete(counts, "a")
+	fmt.Println(len(cfg.(map[string]interface{})), counts)
+}
+
+// Output:
+// 35 5432 4 -1
+// map[a:9 b:1]
+// map[1:23]
+// x 1
+// 5 map[b:1]
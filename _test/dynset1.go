@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type T struct {
+	Name  string
+	Count int
+	tags  []string
+}
+
+func main() {
+	var lst interface{} = []int{1, 2, 3}
+	lst[1] = "9"
+	lst[2] *= 10
+	lst[0]++
+	fmt.Println(lst)
+
+	var t interface{} = &T{tags: []string{"a"}}
+	t.Name = 42
+	t.Count = "3"
+	t.Count += 2
+	t.tags[0] = "b"
+	fmt.Println(t.Name, t.Count, t.tags)
+
+	defer func() {
+		fmt.Println(strings.HasSuffix(fmt.Sprint(recover()), "index out of range [3] with length 3"))
+	}()
+	lst[3] = 4
+}
+
+// Output:
+// [2 9 30]
+// 42 5 [b]
+// true
@@ -0,0 +1,29 @@
+package shape
+
+import "fmt"
+
+const Sides = 4
+
+var Count int
+
+type Shape interface {
+	fmt.Stringer
+	Area() float64
+}
+
+type Rect struct {
+	W, H float64 `json:"w"`
+	Named
+}
+
+type Named struct{ Name string }
+
+type Celsius float64
+
+func (r Rect) Area() float64 { return r.W * r.H }
+
+func (r *Rect) Scale(f float64) { r.W *= f; r.H *= f }
+
+func (r Rect) String() string { return fmt.Sprint(r.W, "x", r.H) }
+
+func New(w, h float64, names ...string) (*Rect, error) { return &Rect{W: w, H: h}, nil }
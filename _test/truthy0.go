@@ -0,0 +1,43 @@
+package main
+
+import "fmt"
+
+type T struct{ a int }
+
+func main() {
+	var (
+		s  []int
+		m  = map[string]int{"a": 1}
+		p  *T
+		t  T
+		z  float64
+		in interface{}
+	)
+
+	if s {
+		fmt.Println("empty slice is true")
+	}
+	if !s {
+		fmt.Println("empty slice is false")
+	}
+	if m && !p {
+		fmt.Println("map is true, nil pointer is false")
+	}
+	if t || z || in {
+		fmt.Println("zero values are true")
+	}
+	s = append(s, 1)
+	p = &T{}
+	t.a = 2
+	fmt.Println(!!s, !!p, !!t, !s || !m)
+	for i := 3; s; i-- {
+		s = s[1:]
+		fmt.Println(i, len(s))
+	}
+}
+
+// Output:
+// empty slice is false
+// map is true, nil pointer is false
+// true true true false
+// 3 0
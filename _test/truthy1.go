@@ -0,0 +1,51 @@
+package main
+
+import "fmt"
+
+type Status struct {
+	code int
+}
+
+func (s Status) Truthy() bool { return s.code == 200 }
+
+type Flag interface {
+	Truthy() bool
+}
+
+type Switch bool
+
+func (s *Switch) Truthy() bool { return bool(*s) }
+
+func main() {
+	ok := Status{200}
+	ko := Status{500}
+
+	if ok {
+		fmt.Println("ok is true")
+	}
+	if !ko {
+		fmt.Println("ko is false")
+	}
+	fmt.Println(ok && ko, ok || ko)
+
+	var f Flag = ko
+	if f {
+		fmt.Println("f is true")
+	} else {
+		fmt.Println("f is false")
+	}
+
+	var sw *Switch
+	fmt.Println(!sw)
+	on := Switch(true)
+	sw = &on
+	fmt.Println(!sw)
+}
+
+// Output:
+// ok is true
+// ko is false
+// false true
+// f is false
+// true
+// false
@@ -15,6 +15,7 @@ The commands are:
     run         execute a Go program from source
     test        execute test functions in a Go package
     version     print version
+    vet         report the implicit conversions of a Go program
 
 Use "yaegi help <command>" for more information about a command.
 
@@ -41,6 +42,8 @@ func help(arg []string) error {
 	case Version:
 		fmt.Println("Usage: yaegi version")
 		return nil
+	case Vet:
+		return vet([]string{"-h"})
 	default:
 		return fmt.Errorf("help: invalid yaegi command: %v", cmd)
 	}
@@ -19,6 +19,8 @@ import (
 func run(arg []string) error {
 	var interactive bool
 	var noAutoImport bool
+	var nilSafe bool
+	var strict bool
 	var tags string
 	var cmd string
 	var err error
@@ -35,6 +37,8 @@ func run(arg []string) error {
 	rflag.StringVar(&tags, "tags", "", "set a list of build tags")
 	rflag.BoolVar(&useUnsafe, "unsafe", useUnsafe, "include unsafe symbols")
 	rflag.BoolVar(&noAutoImport, "noautoimport", false, "do not auto import pre-compiled packages. Import names that would result in collisions (e.g. rand from crypto/rand and rand from math/rand) are automatically renamed (crypto_rand and math_rand)")
+	rflag.BoolVar(&strict, "strict", false, "use strict Go semantics, without implicit conversions")
+	rflag.BoolVar(&nilSafe, "nilsafe", false, "yield nil on missing keys and nil values along dynamic selectors and indexes")
 	rflag.StringVar(&cmd, "e", "", "set the command to be executed (instead of script or/and shell)")
 	rflag.Usage = func() {
 		fmt.Println("Usage: yaegi run [options] [path] [args]")
@@ -51,6 +55,8 @@ func run(arg []string) error {
 		BuildTags:    strings.Split(tags, ","),
 		Env:          os.Environ(),
 		Unrestricted: useUnrestricted,
+		Strict:       strict,
+		NilSafe:      nilSafe,
 	})
 	if err := i.Use(stdlib.Symbols); err != nil {
 		return err
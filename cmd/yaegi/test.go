@@ -27,8 +27,10 @@ func test(arg []string) (err error) {
 		count     string
 		cpu       string
 		failfast  bool
+		nilSafe   bool
 		run       string
 		short     bool
+		strict    bool
 		tags      string
 		timeout   string
 		verbose   bool
@@ -46,8 +48,10 @@ func test(arg []string) (err error) {
 	tflag.StringVar(&count, "count", "", "Run each test and benchmark n times (default 1).")
 	tflag.StringVar(&cpu, "cpu", "", "Specify a list of GOMAXPROCS values for which the tests or benchmarks should be executed.")
 	tflag.BoolVar(&failfast, "failfast", false, "Do not start new tests after the first test failure.")
+	tflag.BoolVar(&nilSafe, "nilsafe", false, "Yield nil on missing keys and nil values along dynamic selectors and indexes.")
 	tflag.StringVar(&run, "run", "", "Run only those tests matching a regular expression.")
 	tflag.BoolVar(&short, "short", false, "Tell long-running tests to shorten their run time.")
+	tflag.BoolVar(&strict, "strict", false, "Use strict Go semantics, without implicit conversions.")
 	tflag.StringVar(&tags, "tags", "", "Set a list of build tags.")
 	tflag.StringVar(&timeout, "timeout", "", "If a test binary runs longer than duration d, panic.")
 	tflag.BoolVar(&useUnrestricted, "unrestricted", useUnrestricted, "Include unrestricted symbols.")
@@ -121,6 +125,8 @@ func test(arg []string) (err error) {
 		BuildTags:    strings.Split(tags, ","),
 		Env:          os.Environ(),
 		Unrestricted: useUnrestricted,
+		Strict:       strict,
+		NilSafe:      nilSafe,
 	})
 	if err := i.Use(stdlib.Symbols); err != nil {
 		return err
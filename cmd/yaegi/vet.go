@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/build"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/yaegi/interp"
+	"github.com/traefik/yaegi/stdlib"
+	"github.com/traefik/yaegi/stdlib/syscall"
+	"github.com/traefik/yaegi/stdlib/unrestricted"
+	"github.com/traefik/yaegi/stdlib/unsafe"
+)
+
+// vetConversion is the JSON form of an implicit conversion.
+type vetConversion struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Site   string `json:"site"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func vet(arg []string) error {
+	var jsonOutput bool
+	var tags string
+
+	// The following flags are initialized from environment.
+	useSyscall, _ := strconv.ParseBool(os.Getenv("YAEGI_SYSCALL"))
+	useUnrestricted, _ := strconv.ParseBool(os.Getenv("YAEGI_UNRESTRICTED"))
+	useUnsafe, _ := strconv.ParseBool(os.Getenv("YAEGI_UNSAFE"))
+
+	vflag := flag.NewFlagSet("vet", flag.ContinueOnError)
+	// The implicit conversions are the only report, always printed.
+	vflag.Bool("implicit", true, "report the implicit conversions of the loose dialect")
+	vflag.BoolVar(&jsonOutput, "json", false, "print the report in JSON")
+	vflag.BoolVar(&useSyscall, "syscall", useSyscall, "include syscall symbols")
+	vflag.BoolVar(&useUnrestricted, "unrestricted", useUnrestricted, "include unrestricted symbols")
+	vflag.StringVar(&tags, "tags", "", "set a list of build tags")
+	vflag.BoolVar(&useUnsafe, "unsafe", useUnsafe, "include unsafe symbols")
+	vflag.Usage = func() {
+		fmt.Println("Usage: yaegi vet [options] path")
+		fmt.Println("Compile a Go program without running it, and report the implicit conversions it relies on.")
+		fmt.Println("Options:")
+		vflag.PrintDefaults()
+	}
+	if err := vflag.Parse(arg); err != nil {
+		return err
+	}
+	args := vflag.Args()
+	if len(args) != 1 {
+		vflag.Usage()
+		return errors.New("expected a single path")
+	}
+
+	i := interp.New(interp.Options{
+		GoPath:       build.Default.GOPATH,
+		BuildTags:    strings.Split(tags, ","),
+		Env:          os.Environ(),
+		Unrestricted: useUnrestricted,
+	})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		return err
+	}
+	if err := i.Use(interp.Symbols); err != nil {
+		return err
+	}
+	if useSyscall {
+		if err := i.Use(syscall.Symbols); err != nil {
+			return err
+		}
+	}
+	if useUnsafe {
+		if err := i.Use(unsafe.Symbols); err != nil {
+			return err
+		}
+	}
+	if useUnrestricted {
+		// Use of unrestricted symbols should always follow stdlib and syscall symbols, to update them.
+		if err := i.Use(unrestricted.Symbols); err != nil {
+			return err
+		}
+	}
+
+	convs, err := i.Analyze(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !jsonOutput {
+		for _, c := range convs {
+			fmt.Println(c)
+		}
+		return nil
+	}
+	out := make([]vetConversion, 0, len(convs))
+	for _, c := range convs {
+		out = append(out, vetConversion{
+			File:   c.Pos.Filename,
+			Line:   c.Pos.Line,
+			Column: c.Pos.Column,
+			Site:   c.Site,
+			Source: c.Source,
+			Target: c.Target,
+		})
+	}
+	b, err := json.MarshalIndent(out, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -58,6 +58,15 @@ Example of a one liner:
 
 	$ yaegi -e 'println(reflect.TypeOf(fmt.Print))'
 
+Vet
+
+The vet command compiles a program without running it, and lists the
+implicit conversions of the loose dialect it relies on, one per line, or
+as a JSON array with -json. The -implicit option selects this report,
+which is the default and only one:
+
+	$ yaegi vet -implicit -json main.go
+
 Options:
 	-e string
 	   evaluate the string and return.
@@ -109,6 +118,7 @@ const (
 	Run     = "run"
 	Test    = "test"
 	Version = "version"
+	Vet     = "vet"
 )
 
 var version = "devel" // This may be overwritten at build time.
@@ -135,6 +145,8 @@ func main() {
 		err = test(os.Args[2:])
 	case Version:
 		fmt.Println(version)
+	case Vet:
+		err = vet(os.Args[2:])
 	default:
 		// If no command is given, fallback to default "run" command.
 		// This allows scripts starting with "#!/usr/bin/env yaegi",
@@ -29,73 +29,60 @@ func {{$name}}(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
-	switch typ.Kind() {
-	case reflect.Interface, reflect.String:
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
 		v0 := genValue(c0)
 		v1 := genValue(c1)
+		dest := genValue(n)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			{{- if $op.Str}}
-			if val0.Kind() == reflect.String || (val0.Kind() == reflect.Interface || val0.Kind() == reflect.Ptr) && val0.Elem().Kind() == reflect.String {
-				value = reflect.ValueOf(rconvToString(val0) + rconvToString(val1))
-			} else {
-			{{- end}}
-				val0 = rconvNumber(val0)
-				val1 = rconvNumber(val1)
-				typ0 := val0.Type()
-				typ1 := val1.Type()
-				if isNumber(typ0) && isNumber(typ1) {
-					switch {
-					case isUint(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Uint() {{$op.Name}} val1.Uint())
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Uint() {{$op.Name}} uint64(val1.Int()))
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Uint() {{$op.Name}} uint64(val1.Float()))
-						}
-					case isInt(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Int() {{$op.Name}} int64(val1.Uint()))
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Int() {{$op.Name}} val1.Int())
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Int() {{$op.Name}} int64(val1.Float()))
-						}
-					{{- if $op.Float}}
-					case isFloat(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Float() {{$op.Name}} float64(val1.Uint()))
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Float() {{$op.Name}} float64(val1.Int()))
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Float() {{$op.Name}} float64(val1.Float()))
-						}
-					{{- else}}
-					case isFloat(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(int64(val0.Float()) {{$op.Name}} int64(val1.Uint()))
-						case isInt(typ1):
-							value = reflect.ValueOf(int64(val0.Float()) {{$op.Name}} int64(val1.Int()))
-						case isFloat(typ1):
-							value = reflect.ValueOf(int64(val0.Float()) {{$op.Name}} int64(val1.Float()))
-						}
-					{{- end}}
-					}
-				} else {
-					panic(n.runErrorf("only numbers support {{$op.Name}} operator"))
+			d := dest(f)
+			d.Set(n.rconv({{$name}}Dynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface, reflect.String:
+		{{- if $op.Str}}
+		if n.interp.strict && typ.Kind() == reflect.String {
+			switch {
+			case isInterface:
+				v0 := genValue(c0)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).Set(reflect.ValueOf(v0(f).String() {{$op.Name}} v1(f).String()).Convert(typ))
+					return next
+				}
+			case c0.rval.IsValid():
+				s0 := vString(c0.rval)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(s0 {{$op.Name}} v1(f).String())
+					return next
+				}
+			case c1.rval.IsValid():
+				v0 := genValue(c0)
+				s1 :=  vString(c1.rval)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(v0(f).String() {{$op.Name}} s1)
+					return next
+				}
+			default:
+				v0 := genValue(c0)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(v0(f).String() {{$op.Name}} v1(f).String())
+					return next
 				}
-			{{- if $op.Str}}			
 			}
-			{{- end}}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			break
+		}
+		{{- end}}
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			dest(f).Set(n.rconv({{$name}}Dynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -288,23 +275,30 @@ func {{$name}}Const(n *node) {
 		} else {
 			operator = token.QUO
 		}
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))			
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(cv0, operator, cv1)
 		n.rval.Set(reflect.ValueOf(v))
 		{{- else}}
 		{{- if $op.Int}}
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))		
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.{{tokenFromName $name}}, constant.ToInt(cv1))
 		{{- else if $op.Str}}
-		cv0 := vConstantValue(v0)
-		cv1 := vConstantValue(v1)
-		cv1 = rconvConst(cv1, cv0.Kind())
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv1 = n.interp.conv.rconvConst(cv1, cv0.Kind())
+		}
 		v := constant.BinaryOp(cv0, token.{{tokenFromName $name}}, cv1)
 		{{- else}}
-		cv0 := rconvConstNumber(vConstantValue(v0))
-		cv1 := rconvConstNumber(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstNumber(cv0), n.interp.conv.rconvConstNumber(cv1)
+		}
 		v := constant.BinaryOp(cv0, token.{{tokenFromName $name}}, cv1)
 		{{- end}}
 		n.rval.Set(reflect.ValueOf(v))
@@ -329,6 +323,54 @@ func {{$name}}Const(n *node) {
 		{{- end}}
 	}
 }
+
+// {{$name}}Dynamic applies the {{$op.Name}} operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func {{$name}}Dynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.{{tokenFromName $name}}, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	{{- if $op.Str}}
+	case reflect.String:
+		r = reflect.ValueOf(o.x0.String() {{$op.Name}} o.x1.String())
+	{{- end}}
+	case reflect.Int64:
+		{{- if $op.Shift}}
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Int() {{$op.Name}} uint64(o.x1.Int()))
+		{{- else}}
+		{{- if or (eq $op.Name "/") (eq $op.Name "%")}}
+		if o.x1.Int() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		{{- end}}
+		r = reflect.ValueOf(o.x0.Int() {{$op.Name}} o.x1.Int())
+		{{- end}}
+	case reflect.Uint64:
+		{{- if $op.Shift}}
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Uint() {{$op.Name}} uint64(o.x1.Int()))
+		{{- else}}
+		{{- if or (eq $op.Name "/") (eq $op.Name "%")}}
+		if o.x1.Uint() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		{{- end}}
+		r = reflect.ValueOf(o.x0.Uint() {{$op.Name}} o.x1.Uint())
+		{{- end}}
+	{{- if $op.Float}}
+	case reflect.Float64:
+		r = reflect.ValueOf(o.x0.Float() {{$op.Name}} o.x1.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(o.x0.Complex() {{$op.Name}} o.x1.Complex())
+	{{- end}}
+	}
+	return r.Convert(o.typ)
+}
 {{end}}
 // Assign operators
 {{range $name, $op := .Arithmetic}}
@@ -336,6 +378,10 @@ func {{$name}}Assign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, {{$name}})
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -344,6 +390,20 @@ func {{$name}}Assign(n *node) {
         indexValue = genValue(c0.child[1])
     }
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set({{$name}}Dynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		{{- if $op.Str}}
@@ -487,6 +547,10 @@ func {{$name}}(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0 := n.child[0]
+	if isDynamicIndex(c0) {
+		incDecIndexGeneric(n, {{$op.Name}}1)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -540,6 +604,27 @@ func {{$name}}(n *node) {
 }
 {{end}}
 {{range $name, $op := .Unary}}
+{{- if not $op.Bool}}
+// {{$name}}Dynamic applies the unary {{$op.Name}} operator to the dynamic operand v,
+// according to the promotion rules of dynamic operators.
+func {{$name}}Dynamic(n *node, v reflect.Value) reflect.Value {
+	o := n.promoteUnary(token.{{tokenFromName $name}}, v)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf({{$op.Name}} o.x0.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf({{$op.Name}} o.x0.Uint())
+	{{- if $op.Float}}
+	case reflect.Float64:
+		r = reflect.ValueOf({{$op.Name}} o.x0.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf({{$op.Name}} o.x0.Complex())
+	{{- end}}
+	}
+	return r.Convert(o.typ)
+}
+{{end}}
 func {{$name}}Const(n *node) {
 	v0 := n.child[0].rval
 	isConst := v0.IsValid() && isConstantValue(v0.Type())
@@ -551,10 +636,14 @@ func {{$name}}Const(n *node) {
 
 	{{- if $op.Bool}}
 	if isConst {
-		v := constant.UnaryOp(token.{{tokenFromName $name}}, rconvConstBool(vConstantValue(v0)), 0)
+		cv0 := vConstantValue(v0)
+		if !n.interp.strict {
+			cv0 = n.interp.conv.rconvConstBool(cv0)
+		}
+		v := constant.UnaryOp(token.{{tokenFromName $name}}, cv0, 0)
 		n.rval.Set(reflect.ValueOf(v))
 	} else {
-		n.rval.SetBool({{$op.Name}} v0.Bool())
+		n.rval.SetBool({{$op.Name}} n.interp.conv.truthy(v0))
 	}
 	{{- else}}
 	switch {
@@ -576,6 +665,31 @@ func {{$name}}Const(n *node) {
 }
 {{end}}
 {{range $name, $op := .Comparison}}
+// {{$name}}Dynamic compares the dynamic operands v0 and v1 with the {{$op.Name}} operator,
+// according to the promotion rules of dynamic operators.
+func {{$name}}Dynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.{{tokenFromName $name}}, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() {{$op.Name}} o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() {{$op.Name}} o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() {{$op.Name}} o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() {{$op.Name}} o.x1.Float()
+	{{- if $op.Complex}}
+	case reflect.Complex128:
+		return o.x0.Complex() {{$op.Name}} o.x1.Complex()
+	case reflect.Bool:
+		return o.x0.Bool() {{$op.Name}} o.x1.Bool()
+	case reflect.Interface:
+		return o.x0.Interface() {{$op.Name}} o.x1.Interface()
+	{{- end}}
+	}
+	panic(n.runErrorf("invalid operation: operator {{$op.Name}} not defined on %v", o.typ))
+}
+
 func {{$name}}(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -586,7 +700,8 @@ func {{$name}}(n *node) {
 
 	{{- if or (eq $op.Name "==") (eq $op.Name "!=") }}
 
-	if c0.typ.cat == aliasT || c1.typ.cat == aliasT {
+	// In strict mode, interface operands are compared as is, without implicit conversion.
+	if c0.typ.cat == aliasT || c1.typ.cat == aliasT || n.interp.strict && (t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface) {
 		switch {
 		case isInterface:
 			v0 := genValue(c0)
@@ -670,36 +785,17 @@ func {{$name}}(n *node) {
 		return
 	}
 
-	// Do not attempt to optimize '==' or '!=' if an operand is an interface.
-	// This will preserve proper dynamic type checking at runtime. For static types,
-	// type checks are already performed, so bypass them if possible.
+	{{- end}}
+
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
 	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				var err error
-				var typ0 reflect.Type
-				if val0.Kind() == reflect.Interface && val0.Elem().IsValid() {
-					typ0 = val0.Elem().Type()
-				} else {
-					typ0 = val0.Type()
-				}
-				val1, err = rconv(val1, typ0)
-				if err != nil {
-					panic(err)
-				}
-				i0 := val0.Interface()
-				i1 := val1.Interface()
-				x, err := compare(i0, i1, "{{$op.Name}}")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "{{$op.Name}}"))
-				}
-
-				if x {
+				if {{$name}}Dynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -709,19 +805,12 @@ func {{$name}}(n *node) {
 		} else {
 			dest := genValue(n)
 			n.exec = func(f *frame) bltn {
-				i0 := v0(f).Interface()
-				i1 := v1(f).Interface()
-				x, err := compare(i0, i1, "{{$op.Name}}")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "{{$op.Name}}"))
-				}
-				dest(f).Set(reflect.ValueOf(x))
+				dest(f).Set(reflect.ValueOf({{$name}}Dynamic(n, v0(f), v1(f))))
 				return tnext
 			}
 		}
 		return
 	}
-	{{- end}}
 
 	switch {
 	case isString(t0) || isString(t1):
@@ -738,7 +827,7 @@ func {{$name}}(n *node) {
 		case c0.rval.IsValid():
 			s0 :=  vString(c0.rval)
 			{{- if or (eq $op.Name "==" ) (eq $op.Name "!=") }}
-			c0Bool := c0.rval.Kind() == reflect.Bool
+			c0Bool := !n.interp.strict && c0.rval.Kind() == reflect.Bool
 			{{- end}}
 			v1 := genValueString(c1)
 			if n.fnext != nil {
@@ -1209,13 +1298,7 @@ func {{$name}}(n *node) {
 			if n.fnext != nil {
 				fnext := getExec(n.fnext)
 				n.exec = func(f *frame) bltn {
-					val0 := v0(f)
-					val1 := v1(f)
-					v, e := rcompare(val0, val1, "{{$op.Name}}")
-					if e != nil {
-						panic(n.runErrorf(e.Error()))
-					}
-					if v {
+					if {{$name}}Dynamic(n, v0(f), v1(f)) {
 						dest(f).SetBool(true)
 						return tnext
 					}
@@ -1224,13 +1307,7 @@ func {{$name}}(n *node) {
 				}
 			} else {
 				n.exec = func(f *frame) bltn {
-					val0 := v0(f)
-					val1 := v1(f)
-					v, e := rcompare(val0, val1, "{{$op.Name}}")
-					if e != nil {
-						panic(n.runErrorf(e.Error()))
-					}
-					dest(f).SetBool(v)
+					dest(f).SetBool({{$name}}Dynamic(n, v0(f), v1(f)))
 					return tnext
 				}
 			}
@@ -1264,6 +1341,18 @@ func main() {
 				return "ADD"
 			case "bitNot":
 				return "XOR"
+			case "equal":
+				return "EQL"
+			case "notEqual":
+				return "NEQ"
+			case "lower":
+				return "LSS"
+			case "lowerEqual":
+				return "LEQ"
+			case "greater":
+				return "GTR"
+			case "greaterEqual":
+				return "GEQ"
 			default:
 				return strings.ToUpper(name)
 			}
@@ -0,0 +1,227 @@
+package interp
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrAllocExceeded is the error returned when an execution allocates more
+// than the limit set by Options.MaxAlloc. It can not be recovered by the
+// interpreted code.
+type ErrAllocExceeded struct {
+	MaxAlloc int64          // allocation limit of the execution, in bytes
+	Position token.Position // position of the allocating expression
+	end      token.Position
+}
+
+func (e *ErrAllocExceeded) Pos() token.Position {
+	return e.Position
+}
+
+func (e *ErrAllocExceeded) End() token.Position {
+	return e.end
+}
+
+func (e *ErrAllocExceeded) Reason() string {
+	return fmt.Sprintf("allocation limit of %d bytes exceeded", e.MaxAlloc)
+}
+
+func (e *ErrAllocExceeded) Error() string {
+	posString := e.Position.String()
+	if e.Position.Filename == DefaultSourceName {
+		posString = strings.TrimPrefix(posString, DefaultSourceName+":")
+	}
+	return fmt.Sprintf("%s %s", posString, e.Reason())
+}
+
+// An allocMeter counts the bytes allocated by an execution.
+type allocMeter struct {
+	used int64 // bytes allocated by the current execution, atomically accessed
+	max  int64 // allocation limit
+}
+
+// charge adds size bytes allocated by node n, and panics if the limit is exceeded.
+func (m *allocMeter) charge(n *node, size int64) {
+	if size <= 0 {
+		return
+	}
+	if size > m.max {
+		size = m.max + 1 // Avoid an overflow of the counter.
+	}
+	if atomic.AddInt64(&m.used, size) > m.max {
+		panic(&ErrAllocExceeded{
+			MaxAlloc: m.max,
+			Position: n.interp.fset.Position(n.pos),
+			end:      n.interp.fset.Position(n.end),
+		})
+	}
+}
+
+// newAllocMeter returns the allocation meter of an execution, or nil if the
+// allocations are not limited.
+func (interp *Interpreter) newAllocMeter() *allocMeter {
+	if interp.maxAlloc <= 0 {
+		return nil
+	}
+	return &allocMeter{max: interp.maxAlloc}
+}
+
+// Allocated returns the approximate number of bytes allocated by the last
+// started execution, as counted for Options.MaxAlloc, or 0 if the allocations
+// are not limited.
+func (interp *Interpreter) Allocated() int64 {
+	interp.mutex.RLock()
+	f := interp.last
+	interp.mutex.RUnlock()
+	if m := f.allocs; m != nil {
+		return atomic.LoadInt64(&m.used)
+	}
+	return 0
+}
+
+// mulSize returns n*size, saturated to math.MaxInt64, or 0 if n is negative.
+func mulSize(n, size int64) int64 {
+	switch {
+	case n <= 0 || size <= 0:
+		return 0
+	case n > math.MaxInt64/size:
+		return math.MaxInt64
+	}
+	return n * size
+}
+
+// allocSize returns the approximate number of bytes held by value v, without
+// following its pointers.
+func allocSize(v reflect.Value) int64 {
+	v = concreteValue(v)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		return mulSize(int64(v.Cap()), int64(v.Type().Elem().Size()))
+	case reflect.Map:
+		t := v.Type()
+		return mulSize(int64(v.Len()), int64(t.Key().Size()+t.Elem().Size()))
+	}
+	return int64(v.Type().Size())
+}
+
+// allocExec makes the exec function of node n charge the bytes allocated by
+// n to the allocation meter of the execution. The sizes of make and new,
+// and of array and struct literals, are charged before the allocation, the
+// growth of append, the other literals and the string concatenations after.
+func allocExec(n *node) {
+	exec := n.exec
+	if exec == nil || n.rval.IsValid() {
+		return
+	}
+
+	switch {
+	case n.kind == callExpr && len(n.child) > 1 && n.child[0].typ != nil && n.child[0].typ.cat == builtinT:
+		switch n.child[0].ident {
+		case bltnMake:
+			size := makeSize(n)
+			n.exec = func(f *frame) bltn {
+				if m := f.allocs; m != nil {
+					m.charge(n, size(f))
+				}
+				return exec(f)
+			}
+		case bltnNew:
+			size := int64(n.child[1].typ.TypeOf().Size())
+			n.exec = func(f *frame) bltn {
+				if m := f.allocs; m != nil {
+					m.charge(n, size)
+				}
+				return exec(f)
+			}
+		case bltnAppend:
+			value := genValue(n.child[1])
+			dest := genValue(n)
+			n.exec = func(f *frame) bltn {
+				m := f.allocs
+				if m == nil {
+					return exec(f)
+				}
+				c := -1
+				if v := concreteValue(value(f)); v.Kind() == reflect.Slice {
+					c = v.Cap()
+				}
+				next := exec(f)
+				if v := concreteValue(dest(f)); v.Kind() == reflect.Slice && v.Cap() != c {
+					m.charge(n, allocSize(v))
+				}
+				return next
+			}
+		}
+
+	case n.action == aCompositeLit && n.typ != nil:
+		if k := n.typ.TypeOf().Kind(); k == reflect.Array || k == reflect.Struct {
+			size := int64(n.typ.TypeOf().Size())
+			n.exec = func(f *frame) bltn {
+				if m := f.allocs; m != nil {
+					m.charge(n, size)
+				}
+				return exec(f)
+			}
+			return
+		}
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			next := exec(f)
+			if m := f.allocs; m != nil {
+				m.charge(n, allocSize(dest(f)))
+			}
+			return next
+		}
+
+	case n.action == aAdd || n.action == aAddAssign:
+		c := n
+		if n.action == aAddAssign {
+			c = n.child[0]
+		}
+		if c.typ == nil {
+			return
+		}
+		if k := c.typ.TypeOf().Kind(); k != reflect.String && k != reflect.Interface {
+			return
+		}
+		dest := genValue(c)
+		n.exec = func(f *frame) bltn {
+			next := exec(f)
+			if m := f.allocs; m != nil {
+				if v := concreteValue(dest(f)); v.Kind() == reflect.String {
+					m.charge(n, int64(v.Len()))
+				}
+			}
+			return next
+		}
+	}
+}
+
+// makeSize returns a function computing the number of bytes allocated by the
+// make call n.
+func makeSize(n *node) func(*frame) int64 {
+	typ := n.child[1].typ.frameType()
+	if len(n.child) < 3 {
+		return func(*frame) int64 { return 0 }
+	}
+	value := genValue(n.lastChild())
+
+	var size int64
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Chan:
+		size = int64(typ.Elem().Size())
+	case reflect.Map:
+		size = int64(typ.Key().Size() + typ.Elem().Size())
+	}
+	return func(f *frame) int64 {
+		return mulSize(vInt(value(f)), size)
+	}
+}
@@ -0,0 +1,104 @@
+package interp
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+)
+
+// An ImplicitConversion is a site where the loose dialect converts a value
+// implicitly, or resolves an operation on the dynamic type of its operands,
+// as reported by Analyze.
+type ImplicitConversion struct {
+	Pos    token.Position // position of the converted expression
+	Site   string         // kind of site, as "assignment", "condition" or "operator +"
+	Source string         // type of the converted value, or of the operands
+	Target string         // type the value is converted to, or the result type
+}
+
+func (c ImplicitConversion) String() string {
+	return fmt.Sprintf("%v: %s: %s -> %s", c.Pos, c.Site, c.Source, c.Target)
+}
+
+// implicitReport collects the implicit conversions found while analyzing.
+type implicitReport struct {
+	convs []ImplicitConversion
+	seen  map[implicitSite]bool
+}
+
+// implicitSite identifies a recorded conversion, as a node may be
+// type checked more than once.
+type implicitSite struct {
+	pos  token.Pos
+	site string
+}
+
+// Analyze compiles the Go code located at path, a file or a package directory
+// as for EvalPath, without running it, and returns the implicit conversions of
+// the loose dialect the code relies on, in source order: the values assigned,
+// passed, returned, sent or used as keys or composite literal elements with a
+// type not assignable to their destination, the non-bool conditions and logical
+// operands, the dynamic selectors and indexes, and the operators applied to
+// interface or mismatched operands. Nothing is reported in strict mode. The
+// compiled packages are not kept in the interpreter.
+func (interp *Interpreter) Analyze(path string) ([]ImplicitConversion, error) {
+	a := interp.analyzer()
+	if _, err := a.EvalPath(path); err != nil {
+		return nil, err
+	}
+
+	convs := a.implicit.convs
+	sort.SliceStable(convs, func(i, j int) bool {
+		a, b := convs[i].Pos, convs[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return convs, nil
+}
+
+// analyzer returns an interpreter sharing the options, binary packages and
+// hooks of interp, to compile code without running it nor registering its
+// packages in interp.
+func (interp *Interpreter) analyzer() *Interpreter {
+	a := New(Options{})
+	a.opt = interp.opt
+	a.noRun = true
+	a.binPkg = interp.binPkg
+	for k := range interp.binPkg {
+		a.pkgNames[k] = interp.pkgNames[k]
+	}
+	a.hooks, a.conv = interp.hooks, interp.conv
+	interp.mutex.RLock()
+	a.policies = make(map[string]*SymbolPolicy, len(interp.policies))
+	for k, p := range interp.policies {
+		a.policies[k] = p
+	}
+	interp.mutex.RUnlock()
+	a.implicit = &implicitReport{seen: map[implicitSite]bool{}}
+	return a
+}
+
+// noteImplicit records, while analyzing, the implicit conversion of the
+// value of n from type source to type target at a site of the given kind.
+func (interp *Interpreter) noteImplicit(n *node, site, source, target string) {
+	r := interp.implicit
+	if r == nil || interp.strict {
+		return
+	}
+	k := implicitSite{n.pos, site}
+	if r.seen[k] {
+		return
+	}
+	r.seen[k] = true
+	r.convs = append(r.convs, ImplicitConversion{Pos: interp.fset.Position(n.pos), Site: site, Source: source, Target: target})
+}
+
+// noteBool records, while analyzing, the implicit conversion to bool of the
+// value of n, if it is not a boolean.
+func (interp *Interpreter) noteBool(n *node, site string) {
+	if interp.implicit != nil && n.typ != nil && !isBool(n.typ) {
+		interp.noteImplicit(n, site, n.typ.id(), "bool")
+	}
+}
@@ -0,0 +1,172 @@
+package interp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Bind stores the function name into the Go function variable pointed to by
+// fnPtr. The name is either the name of a function of the main package, or a
+// qualified name pkg.Fn, where pkg is the import path or the name of a source
+// or binary package.
+//
+// If the signature of the function differs from the one of the variable, the
+// function is adapted: the arguments and results are converted implicitly
+// when the function is called, and a conversion failure panics. The number of
+// parameters and results must match, except that the trailing arguments are
+// collected into the final parameter of a variadic function. In strict mode,
+// the parameter types of the variable must be assignable to the ones of the
+// function, and the result types of the function to the ones of the variable.
+func (interp *Interpreter) Bind(name string, fnPtr interface{}) error {
+	ptr := reflect.ValueOf(fnPtr)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Func {
+		return fmt.Errorf("bind %s: want a non-nil pointer to a function variable, got %T", name, fnPtr)
+	}
+	dest := ptr.Elem()
+	ft := dest.Type()
+
+	fn, err := interp.lookupFunc(name)
+	if err != nil {
+		return err
+	}
+	if fn.Type().AssignableTo(ft) {
+		dest.Set(fn)
+		return nil
+	}
+	if err := interp.checkBind(name, fn.Type(), ft); err != nil {
+		return err
+	}
+	dest.Set(reflect.MakeFunc(ft, interp.adaptFunc(name, fn, ft)))
+	return nil
+}
+
+// lookupFunc returns the value of the function name, as given to Bind.
+func (interp *Interpreter) lookupFunc(name string) (reflect.Value, error) {
+	pkg, id := mainID, name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		pkg, id = name[:i], name[i+1:]
+	}
+
+	interp.mutex.RLock()
+	defer interp.mutex.RUnlock()
+
+	var sym *symbol
+	switch {
+	case pkg == mainID && interp.scopes[mainID] != nil && interp.scopes[mainID].sym[id] != nil:
+		sym = interp.scopes[mainID].sym[id]
+	case interp.srcPkg[pkg] != nil:
+		sym = interp.srcPkg[pkg][id]
+	case interp.binPkg[pkg] != nil:
+		if v, ok := interp.binPkg[pkg][id]; ok && v.Kind() == reflect.Func {
+			return v, nil
+		}
+	default:
+		for path, pkgName := range interp.pkgNames {
+			if pkgName == pkg && interp.srcPkg[path] != nil {
+				sym = interp.srcPkg[path][id]
+				break
+			}
+		}
+	}
+
+	if sym != nil {
+		switch sym.kind {
+		case funcSym:
+			return genFunctionWrapper(sym.node)(interp.frame), nil
+		case varSym:
+			if sym.index >= len(interp.frame.data) {
+				break
+			}
+			v := interp.frame.data[sym.index]
+			if !v.IsValid() || !v.CanInterface() {
+				break
+			}
+			if n, ok := v.Interface().(*node); ok {
+				return genFunctionWrapper(n)(interp.frame), nil
+			}
+			if v.Kind() == reflect.Func && !v.IsNil() {
+				return v, nil
+			}
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("bind %s: undefined function", name)
+}
+
+// checkBind returns an error if the function of type fnt can not be adapted to
+// the function type ft.
+func (interp *Interpreter) checkBind(name string, fnt, ft reflect.Type) error {
+	switch {
+	case ft.IsVariadic() && !fnt.IsVariadic():
+		return fmt.Errorf("bind %s: cannot use variadic %v as %v", name, ft, fnt)
+	case fnt.IsVariadic() && !ft.IsVariadic() && ft.NumIn() < fnt.NumIn()-1:
+		return fmt.Errorf("bind %s: %v has %d parameters, want at least %d", name, ft, ft.NumIn(), fnt.NumIn()-1)
+	case (ft.IsVariadic() || !fnt.IsVariadic()) && ft.NumIn() != fnt.NumIn():
+		return fmt.Errorf("bind %s: %v has %d parameters, want %d", name, ft, ft.NumIn(), fnt.NumIn())
+	case ft.NumOut() != fnt.NumOut():
+		return fmt.Errorf("bind %s: %v has %d results, want %d", name, ft, ft.NumOut(), fnt.NumOut())
+	case !interp.strict:
+		return nil
+	}
+
+	for i := 0; i < ft.NumIn(); i++ {
+		t, fnParam := ft.In(i), paramType(fnt, i)
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			t = t.Elem()
+		}
+		if !t.AssignableTo(fnParam) {
+			return fmt.Errorf("bind %s: cannot use %v as %v in parameter %d", name, t, fnParam, i)
+		}
+	}
+	for i := 0; i < ft.NumOut(); i++ {
+		if !fnt.Out(i).AssignableTo(ft.Out(i)) {
+			return fmt.Errorf("bind %s: cannot use %v as %v in result %d", name, fnt.Out(i), ft.Out(i), i)
+		}
+	}
+	return nil
+}
+
+// paramType returns the type of the i-th argument of a call to a function of
+// type fnt, the element type of the final parameter for the variadic ones.
+func paramType(fnt reflect.Type, i int) reflect.Type {
+	if last := fnt.NumIn() - 1; fnt.IsVariadic() && i >= last {
+		return fnt.In(last).Elem()
+	}
+	return fnt.In(i)
+}
+
+// adaptFunc returns the implementation of a function of type ft calling fn,
+// with the arguments and results converted.
+func (interp *Interpreter) adaptFunc(name string, fn reflect.Value, ft reflect.Type) func([]reflect.Value) []reflect.Value {
+	fnt := fn.Type()
+	return func(args []reflect.Value) []reflect.Value {
+		if ft.IsVariadic() {
+			last := args[len(args)-1]
+			args = args[:len(args)-1]
+			for i := 0; i < last.Len(); i++ {
+				args = append(args, last.Index(i))
+			}
+		}
+
+		in := make([]reflect.Value, len(args))
+		for i, a := range args {
+			v, err := interp.bindValue(a.Interface(), paramType(fnt, i))
+			if err != nil {
+				panic(fmt.Errorf("%s: argument %d: %w", name, i, err))
+			}
+			in[i] = v
+		}
+
+		out := fn.Call(in)
+		for i, r := range out {
+			v, err := interp.bindValue(r.Interface(), ft.Out(i))
+			if err != nil {
+				panic(fmt.Errorf("%s: result %d: %w", name, i, err))
+			}
+			// The results of a function made by MakeFunc must have the exact types.
+			out[i] = reflect.New(ft.Out(i)).Elem()
+			out[i].Set(v)
+		}
+		return out
+	}
+}
@@ -0,0 +1,167 @@
+package interp
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrBudgetExceeded is the error returned when an execution exceeds its budget.
+// It can not be recovered by the interpreted code.
+type ErrBudgetExceeded struct {
+	Budget   int64          // budget of the execution
+	Position token.Position // position of the node reached when the budget was exceeded
+	end      token.Position
+}
+
+func (e *ErrBudgetExceeded) Pos() token.Position {
+	return e.Position
+}
+
+func (e *ErrBudgetExceeded) End() token.Position {
+	return e.end
+}
+
+func (e *ErrBudgetExceeded) Reason() string {
+	return fmt.Sprintf("execution budget of %d exceeded", e.Budget)
+}
+
+func (e *ErrBudgetExceeded) Error() string {
+	posString := e.Position.String()
+	if e.Position.Filename == DefaultSourceName {
+		posString = strings.TrimPrefix(posString, DefaultSourceName+":")
+	}
+	return fmt.Sprintf("%s %s", posString, e.Reason())
+}
+
+// A meter counts the cost of the nodes executed by an execution.
+type meter struct {
+	used   int64 // cost of the current execution, atomically accessed
+	budget int64 // budget of the current execution, or 0, atomically accessed
+}
+
+// charge adds the cost of executing node n, and panics if the budget is exceeded.
+func (m *meter) charge(n *node, cost int64) {
+	used := atomic.AddInt64(&m.used, cost)
+	if budget := atomic.LoadInt64(&m.budget); budget > 0 && used > budget {
+		panic(&ErrBudgetExceeded{
+			Budget:   budget,
+			Position: n.interp.fset.Position(n.pos),
+			end:      n.interp.fset.Position(n.end),
+		})
+	}
+}
+
+// reset starts a new execution with the given budget.
+func (m *meter) reset(budget int64) {
+	atomic.StoreInt64(&m.budget, budget)
+	atomic.StoreInt64(&m.used, 0)
+}
+
+type budgetKey struct{}
+
+// WithBudget returns a copy of ctx setting the budget of the execution run by
+// EvalWithContext, EvalPathWithContext, EvalIntoWithContext or
+// ExecuteWithContext, instead of Options.Budget. A budget of zero or less
+// means no limit. It has no effect if the metering is disabled in the options
+// of the interpreter.
+func WithBudget(ctx context.Context, budget int64) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// budgetOf returns the budget of an execution with context ctx.
+func (interp *Interpreter) budgetOf(ctx context.Context) int64 {
+	if b, ok := ctx.Value(budgetKey{}).(int64); ok {
+		return b
+	}
+	return interp.budget
+}
+
+// newMeter returns the meter of an execution with the given budget, or nil if
+// the metering is disabled.
+func (interp *Interpreter) newMeter(budget int64) *meter {
+	if !interp.metered {
+		return nil
+	}
+	return &meter{budget: budget}
+}
+
+// arm resets with the given budget the meters of the global frame, which count
+// the code run outside of an execution frame: the initialization of the
+// imported source packages, and the calls of the function values obtained
+// from the interpreter.
+func (interp *Interpreter) arm(budget int64) {
+	if m := interp.frame.meter; m != nil {
+		m.reset(budget)
+	}
+	if m := interp.frame.allocs; m != nil {
+		atomic.StoreInt64(&m.used, 0)
+	}
+	interp.mutex.Lock()
+	interp.last = interp.frame
+	interp.mutex.Unlock()
+}
+
+// execFrame returns the frame of a new execution with the given budget. It
+// shares the data of the global frame, but has its own meters, inherited by
+// the calls and the goroutines of the execution, so that the concurrent
+// executions are metered separately. It must be called after resizeFrame.
+func (interp *Interpreter) execFrame(budget int64) *frame {
+	f := interp.frame.clone(false)
+	f.meter = interp.newMeter(budget)
+	f.allocs = interp.newAllocMeter()
+	interp.mutex.Lock()
+	interp.last = f
+	interp.mutex.Unlock()
+	return f
+}
+
+// catchBudget sets *err to the error of a panic for an exceeded budget or
+// allocation limit, and propagates the other panics.
+func (interp *Interpreter) catchBudget(err *error) {
+	r := recover()
+	switch e := r.(type) {
+	case *ErrBudgetExceeded:
+		*err = e
+		return
+	case *ErrAllocExceeded:
+		*err = e
+		return
+	}
+	if r != nil {
+		panic(r)
+	}
+}
+
+// Cost returns the cost of the nodes executed by the last started execution,
+// as counted for its budget, or 0 if the metering is disabled.
+func (interp *Interpreter) Cost() int64 {
+	interp.mutex.RLock()
+	f := interp.last
+	interp.mutex.RUnlock()
+	if m := f.meter; m != nil {
+		return atomic.LoadInt64(&m.used)
+	}
+	return 0
+}
+
+// meterExec makes the exec function of node n charge the cost of n to the
+// meter of the execution, before executing n.
+func meterExec(n *node) {
+	cost, ok := n.interp.budgetCosts[n.kind.String()]
+	if !ok {
+		cost = 1
+	}
+	exec := n.exec
+	if cost == 0 || exec == nil {
+		return
+	}
+	n.exec = func(f *frame) bltn {
+		if m := f.meter; m != nil {
+			m.charge(n, cost)
+		}
+		return exec(f)
+	}
+}
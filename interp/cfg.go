@@ -42,7 +42,7 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 	if sc == nil {
 		sc = interp.initScopePkg(importPath, pkgName)
 	}
-	check := typecheck{scope: sc}
+	check := typecheck{scope: sc, conv: interp.conv, strict: interp.strict}
 	var initNodes []*node
 	var err error
 
@@ -137,11 +137,20 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 						k, o = n.anc.child[0], n.anc.child[1]
 					}
 
-					switch o.typ.cat {
+					ocat, otyp := o.typ.cat, o.typ.rtype
+					if ocat == interfaceT {
+						// Range over an interface, according to its dynamic type.
+						ocat, otyp = valueT, o.typ.TypeOf()
+					}
+					switch ocat {
 					case valueT:
-						typ := o.typ.rtype
+						typ := otyp
 						switch typ.Kind() {
 						case reflect.Interface:
+							if interp.strict {
+								err = o.cfgErrorf("cannot range over %s", o.typ.id())
+								return false
+							}
 							n.anc.gen = rangeDynamic
 							sc.add(sc.getType("interface{}")) // for map / array to be ranged over
 							ktyp = sc.getType("interface{}")  // idx
@@ -630,8 +639,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 					// Setting a map entry requires an additional step, do not optimize.
 					// As we only write, skip the default useless getIndexMap dest action.
 					dest.gen = nop
+				case isDynamicIndex(dest):
+					// Setting through a dynamic selector or index requires an additional step, do not optimize.
 				case isFuncField(dest):
 					// Setting a struct field of function type requires an extra step. Do not optimize.
+				case needsImplicitConv(src, dest.typ):
+					// The source value is converted by the assign operation. Do not optimize.
 				case isCall(src) && !isInterfaceSrc(dest.typ) && n.kind != defineStmt:
 					// Call action may perform the assignment directly.
 					if dest.typ.id() != src.typ.id() {
@@ -757,6 +770,11 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 			nilSym := interp.universe.sym[nilIdent]
 			c0, c1 := n.child[0], n.child[1]
 
+			if !interp.strict && n.typ != nil && (isMismatched(c0, n.typ) || isMismatched(c1, n.typ)) {
+				// The type propagated from the assigned destination does not apply to
+				// typed operands of another type: the result is converted at assignment.
+				n.typ = nil
+			}
 			err = check.binaryExpr(n)
 			if err != nil {
 				break
@@ -790,6 +808,7 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 					break
 				}
 			}
+			check.noteOperator(n)
 			if c0.rval.IsValid() && c1.rval.IsValid() && (!isInterface(n.typ)) && constOp[n.action] != nil {
 				n.typ.TypeOf()       // Force compute of reflection type.
 				constOp[n.action](n) // Compute a constant result now rather than during exec.
@@ -800,14 +819,14 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 				// by constOp and available in n.rval. Nothing else to do at execution.
 				n.gen = nop
 				n.findex = notInFrame
-			case n.anc.kind == assignStmt && n.anc.action == aAssign && n.anc.nleft == 1:
+			case n.anc.kind == assignStmt && n.anc.action == aAssign && n.anc.nleft == 1 && !needsImplicitConv(n, n.anc.child[childPos(n)-n.anc.nright].typ):
 				// To avoid a copy in frame, if the result is to be assigned, store it directly
 				// at the frame location of destination.
 				dest := n.anc.child[childPos(n)-n.anc.nright]
 				n.typ = dest.typ
 				n.findex = dest.findex
 				n.level = dest.level
-			case n.anc.kind == returnStmt:
+			case n.anc.kind == returnStmt && !needsImplicitConv(n, sc.def.typ.ret[childPos(n)]):
 				// To avoid a copy in frame, if the result is to be returned, store it directly
 				// at the frame location reserved for output arguments.
 				pos := childPos(n)
@@ -885,8 +904,13 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 					err = n.cfgErrorf("type %v does not support indexing", typ)
 				}
 			case reflect.Interface:
-				n.gen = getIndexGeneric
 				checkIndex = false
+				if interp.strict {
+					err = n.cfgErrorf("type %s does not support indexing", t.id())
+					break
+				}
+				n.gen = getIndexGeneric
+				interp.noteImplicit(n, "dynamic index", t.id(), "interface{}")
 			default:
 				err = n.cfgErrorf("type is not an array, slice, string or map: %v", t.id())
 			}
@@ -919,6 +943,11 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 			wireChild(n)
 
 		case declStmt, exprStmt, sendStmt:
+			if n.kind == sendStmt {
+				if err = check.sendStmt(n); err != nil {
+					break
+				}
+			}
 			wireChild(n)
 			l := n.lastChild()
 			n.findex = l.findex
@@ -1257,12 +1286,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case forStmt2: // for cond {}
 			cond, body := n.child[0], n.child[1]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as for condition")
 			}
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					n.start = body.start
 					body.tnext = body.start
 				}
@@ -1276,13 +1305,13 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case forStmt3: // for init; cond; {}
 			init, cond, body := n.child[0], n.child[1], n.child[2]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as for condition")
 			}
 			n.start = init.start
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					init.tnext = body.start
 					body.tnext = body.start
 				} else {
@@ -1305,12 +1334,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case forStmt5: // for ; cond; post {}
 			cond, post, body := n.child[0], n.child[1], n.child[2]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as for condition")
 			}
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					n.start = body.start
 					post.tnext = body.start
 				}
@@ -1333,13 +1362,13 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case forStmt7: // for init; cond; post {}
 			init, cond, post, body := n.child[0], n.child[1], n.child[2], n.child[3]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as for condition")
 			}
 			n.start = init.start
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					init.tnext = body.start
 					post.tnext = body.start
 				} else {
@@ -1426,12 +1455,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case ifStmt0: // if cond {}
 			cond, tbody := n.child[0], n.child[1]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as if condition")
 			}
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					n.start = tbody.start
 				}
 			} else {
@@ -1444,12 +1473,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case ifStmt1: // if cond {} else {}
 			cond, tbody, fbody := n.child[0], n.child[1], n.child[2]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as if condition")
 			}
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test and the useless branch.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					n.start = tbody.start
 				} else {
 					n.start = fbody.start
@@ -1465,13 +1494,13 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case ifStmt2: // if init; cond {}
 			init, cond, tbody := n.child[0], n.child[1], n.child[2]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as if condition")
 			}
 			n.start = init.start
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					init.tnext = tbody.start
 				} else {
 					init.tnext = n
@@ -1486,13 +1515,13 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 
 		case ifStmt3: // if init; cond {} else {}
 			init, cond, tbody, fbody := n.child[0], n.child[1], n.child[2], n.child[3]
-			if !canIconvBool(cond.typ) {
+			if !interp.isCond(cond) {
 				err = cond.cfgErrorf("non-bool used as if condition")
 			}
 			n.start = init.start
 			if cond.rval.IsValid() {
 				// Condition is known at compile time, bypass test.
-				if rconvToBool(cond.rval) {
+				if interp.conv.truthy(cond.rval) {
 					init.tnext = tbody.start
 				} else {
 					init.tnext = fbody.start
@@ -1522,7 +1551,9 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 			n.child[0].tnext = n.child[1].start
 			setFNext(n.child[0], n)
 			n.child[1].tnext = n
-			n.typ = n.child[0].typ
+			n.typ = logicalType(sc, n.child[0])
+			interp.noteBool(n.child[0], "operator &&")
+			interp.noteBool(n.child[1], "operator &&")
 			n.findex, err = sc.add(n.typ)
 			if err != nil {
 				panic(n.cfgErrorf(err.Error()))
@@ -1540,7 +1571,9 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 			n.child[0].tnext = n
 			setFNext(n.child[0], n.child[1].start)
 			n.child[1].tnext = n
-			n.typ = n.child[0].typ
+			n.typ = logicalType(sc, n.child[0])
+			interp.noteBool(n.child[0], "operator ||")
+			interp.noteBool(n.child[1], "operator ||")
 			n.findex, err = sc.add(n.typ)
 			if err != nil {
 				panic(n.cfgErrorf(err.Error()))
@@ -1612,6 +1645,15 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 				}
 				// TODO(mpl): move any of that code to typecheck?
 				c.typ.node = c
+				if interp.strict && !c.typ.assignableTo(typ) {
+					err = c.cfgErrorf("cannot use %v (type %v) as type %v in return argument", c.ident, c.typ.id(), typ.id())
+					return
+				}
+				if !interp.strict && c.typ.cat != nilT {
+					if err = check.assignment(c, typ, "return argument"); err != nil {
+						return
+					}
+				}
 				if c.typ.cat == nilT {
 					// nil: Set node value to zero of return type
 					if typ.cat == funcT {
@@ -1637,6 +1679,9 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 				name := n.child[1].ident
 				pkg := n.child[0].sym.typ.path
 				if s, ok := interp.binPkg[pkg][name]; ok {
+					if err = interp.checkSymbol(n, pkg, name); err != nil {
+						break
+					}
 					if isBinType(s) {
 						n.typ = valueTOf(s.Type().Elem())
 					} else {
@@ -1733,6 +1778,9 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 				if n.typ.cat == valueT || n.typ.cat == errorT {
 					switch method, ok := n.typ.rtype.MethodByName(n.child[1].ident); {
 					case ok:
+						if err = interp.checkBinMethod(n, n.typ.rtype); err != nil {
+							break
+						}
 						hasRecvType := n.typ.rtype.Kind() != reflect.Interface
 						n.val = method.Index
 						n.gen = getIndexBinMethod
@@ -1762,26 +1810,34 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 						// method lookup failed on type, now lookup on pointer to type
 						pt := reflect.PtrTo(n.typ.rtype)
 						if m2, ok2 := pt.MethodByName(n.child[1].ident); ok2 {
+							if err = interp.checkBinMethod(n, pt); err != nil {
+								break
+							}
 							n.val = m2.Index
 							n.gen = getIndexBinPtrMethod
 							n.typ = valueTOf(m2.Type, isBinMethod(), withRecv(valueTOf(pt)))
 							n.recv = &receiver{node: n.child[0]}
 							n.action = aGetMethod
 							break
+						} else if interp.strict {
+							err = n.cfgErrorf("undefined field or method: %s", n.child[1].ident)
 						} else {
 							n.gen = getIndexGeneric
 							n.typ = valueTOf(reflect.TypeOf((*interface{})(nil)).Elem())
+							interp.noteImplicit(n, "dynamic selector", n.child[0].typ.id(), "interface{}")
 						}
 					}
 				} else if n.typ.cat == ptrT && (n.typ.val.cat == valueT || n.typ.val.cat == errorT) {
 					// Handle pointer on object defined in runtime
 					if method, ok := n.typ.val.rtype.MethodByName(n.child[1].ident); ok {
+						err = interp.checkBinMethod(n, n.typ.val.rtype)
 						n.val = method.Index
 						n.typ = valueTOf(method.Type, isBinMethod(), withRecv(n.typ))
 						n.recv = &receiver{node: n.child[0]}
 						n.gen = getIndexBinElemMethod
 						n.action = aGetMethod
 					} else if method, ok := reflect.PtrTo(n.typ.val.rtype).MethodByName(n.child[1].ident); ok {
+						err = interp.checkBinMethod(n, n.typ.val.rtype)
 						n.val = method.Index
 						n.gen = getIndexBinMethod
 						n.typ = valueTOf(method.Type, withRecv(valueTOf(reflect.PtrTo(n.typ.val.rtype), isBinMethod())))
@@ -1812,6 +1868,7 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 						n.recv = &receiver{node: n.child[0], index: lind}
 					}
 				} else if m, lind, isPtr, ok := n.typ.lookupBinMethod(n.child[1].ident); ok {
+					err = interp.checkBinMethod(n, n.typ.fieldSeq(lind).TypeOf())
 					n.action = aGetMethod
 					switch {
 					case isPtr && n.typ.fieldSeq(lind).cat != ptrT:
@@ -1824,9 +1881,12 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 					n.recv = &receiver{node: n.child[0], index: lind}
 					n.val = append([]int{m.Index}, lind...)
 					n.typ = valueTOf(m.Type, isBinMethod(), withRecv(n.child[0].typ))
+				} else if interp.strict {
+					err = n.cfgErrorf("undefined selector: %s", n.child[1].ident)
 				} else {
 					n.gen = getIndexGeneric
 					n.typ = valueTOf(reflect.TypeOf((*interface{})(nil)).Elem())
+					interp.noteImplicit(n, "dynamic selector", n.child[0].typ.id(), "interface{}")
 				}
 			}
 			if err == nil && n.findex != -1 {
@@ -2088,6 +2148,10 @@ func (interp *Interpreter) cfg(root *node, sc *scope, importPath, pkgName string
 			}
 
 			n.typ = n.child[0].typ
+			if n.action == aNot {
+				n.typ = logicalType(sc, n.child[0])
+				interp.noteBool(n.child[0], "operator !")
+			}
 			if n.action == aRecv {
 				// Channel receive operation: set type to the channel data type
 				if n.typ.cat == valueT {
@@ -2370,6 +2434,15 @@ func getVarDependencies(nod *node, sc *scope) (deps []*node) {
 	return deps
 }
 
+// logicalType returns the type of a logical operation on operand c: the type
+// of c if it can hold a bool, or bool for other operands with a truthiness.
+func logicalType(sc *scope, c *node) *itype {
+	if canIconvBool(c.typ) {
+		return c.typ
+	}
+	return sc.getType("bool")
+}
+
 // setFnext sets the cond fnext field to next, propagates it for parenthesis blocks
 // and sets the action to branch.
 func setFNext(cond, next *node) {
@@ -2647,10 +2720,31 @@ func isFuncField(n *node) bool {
 	return isField(n) && isFunc(n.typ)
 }
 
+// isMismatched returns true if n is a typed operand, not a constant nor an
+// interface, whose type differs from typ.
+func isMismatched(n *node, typ *itype) bool {
+	return n.typ != nil && !n.rval.IsValid() && isMismatchedType(n.typ, typ)
+}
+
+// isMismatchedType returns true if t is a complete typed type, not an
+// interface, whose runtime type differs from the one of typ.
+func isMismatchedType(t, typ *itype) bool {
+	if t.untyped || t.incomplete || typ.incomplete || isInterface(t) || isInterface(typ) {
+		return false
+	}
+	return t.TypeOf() != typ.TypeOf()
+}
+
 func isMapEntry(n *node) bool {
 	return n.action == aGetIndex && isMap(n.child[0].typ)
 }
 
+// isDynamicIndex returns true if n is a selector or index expression
+// resolved at runtime on a value of unknown type (loose mode only).
+func isDynamicIndex(n *node) bool {
+	return n.action == aGetIndex && n.gen != nil && reflect.ValueOf(n.gen).Pointer() == reflect.ValueOf(getIndexGeneric).Pointer()
+}
+
 func isCall(n *node) bool {
 	return n.action == aCall || n.action == aCallSlice
 }
@@ -2749,6 +2843,12 @@ func setExec(n *node) {
 			}
 		}
 		n.gen(n)
+		if n.interp != nil && n.interp.allocExec != nil {
+			n.interp.allocExec(n)
+		}
+		if n.interp != nil && n.interp.metered {
+			meterExec(n)
+		}
 	}
 
 	set(n)
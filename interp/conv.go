@@ -4,15 +4,208 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/jinzhu/copier"
 	"github.com/spf13/cast"
 	"go/constant"
+	"go/token"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-func canIconv(typ *itype, expected *itype) bool {
+// converter performs the implicit conversions of an interpreter. The convert
+// hooks registered with Use are consulted first, then the builtin coercions,
+// then the builtin rules apply. A nil converter applies only the builtin
+// coercions and rules.
+type converter struct {
+	hooks  *hooks
+	policy *ConversionPolicy
+
+	mutex sync.RWMutex
+	cache map[convKey]convEntry // hook and coercion lookups
+}
+
+type convKey struct{ from, to reflect.Type }
+
+// convEntry holds the conversion functions found for a pair of types.
+type convEntry struct {
+	hook   func(src, dest reflect.Value) // registered convert hook, nil if none applies
+	coerce coerceFn                      // builtin coercion, nil if none applies
+}
+
+func newConverter(h *hooks, p ConversionPolicy) *converter {
+	return &converter{hooks: h, policy: &p, cache: map[convKey]convEntry{}}
+}
+
+var defaultPolicy ConversionPolicy
+
+// rules returns the conversion policy of c, or the default one if c is nil.
+func (c *converter) rules() *ConversionPolicy {
+	if c == nil {
+		return &defaultPolicy
+	}
+	return c.policy
+}
+
+// lookup returns the registered conversion function from type from to type to,
+// or nil if there is none.
+func (c *converter) lookup(from, to reflect.Type) func(src, dest reflect.Value) {
+	if c == nil || len(c.hooks.convert) == 0 || from == nil || to == nil {
+		return nil
+	}
+	return c.entry(from, to).hook
+}
+
+// coercion returns the builtin coercion from type from to type to, or nil
+// if there is none.
+func (c *converter) coercion(from, to reflect.Type) coerceFn {
+	if c == nil {
+		return coercionOf(from, to)
+	}
+	return c.entry(from, to).coerce
+}
+
+// entry returns the conversion functions from type from to type to.
+// Results are cached per pair of types.
+func (c *converter) entry(from, to reflect.Type) convEntry {
+	key := convKey{from, to}
+	c.mutex.RLock()
+	e, ok := c.cache[key]
+	c.mutex.RUnlock()
+	if ok {
+		return e
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, con := range c.hooks.convert {
+		if e.hook = con(from, to); e.hook != nil {
+			break
+		}
+	}
+	e.coerce = coercionOf(from, to)
+	c.cache[key] = e
+	return e
+}
+
+// reset clears the lookup cache, after convert hooks have been registered.
+func (c *converter) reset() {
+	c.mutex.Lock()
+	c.cache = map[convKey]convEntry{}
+	c.mutex.Unlock()
+}
+
+var (
+	errNilConversion     = errors.New("cannot convert nil to a non nullable type")
+	errArrayConversion   = errors.New("only slices and arrays convert to arrays")
+	errPointerConversion = errors.New("pointers only convert in place, to pointers to identical underlying types")
+	errFuncConversion    = errors.New("funcs only convert to funcs of identical signature")
+)
+
+// parseBool converts s to a bool, using the policy vocabularies if any.
+func (p *ConversionPolicy) parseBool(s string) (bool, error) {
+	if len(p.TrueStrings) == 0 && len(p.FalseStrings) == 0 {
+		return strconv.ParseBool(s)
+	}
+	for _, t := range p.TrueStrings {
+		if strings.EqualFold(s, t) {
+			return true, nil
+		}
+	}
+	for _, f := range p.FalseStrings {
+		if strings.EqualFold(s, f) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid boolean string %q", s)
+}
+
+// formatBool converts b to a string, using the policy vocabularies if any.
+func (p *ConversionPolicy) formatBool(b bool) string {
+	switch {
+	case b && len(p.TrueStrings) > 0:
+		return p.TrueStrings[0]
+	case !b && len(p.FalseStrings) > 0:
+		return p.FalseStrings[0]
+	}
+	return strconv.FormatBool(b)
+}
+
+// formatFloat converts f to a string, using the policy format, precision
+// and decimal separator.
+func (p *ConversionPolicy) formatFloat(f float64, bitSize int) string {
+	var s string
+	if p.FloatFormat != 0 {
+		s = strconv.FormatFloat(f, p.FloatFormat, p.FloatPrecision, bitSize)
+	} else {
+		s = strconv.FormatFloat(f, 'f', -1, bitSize)
+	}
+	if p.DecimalSeparator != 0 && p.DecimalSeparator != '.' {
+		s = strings.Replace(s, ".", string(p.DecimalSeparator), 1)
+	}
+	return s
+}
+
+// number prepares the string s for a conversion to a number, replacing
+// the decimal separator and the empty string according to the policy.
+func (p *ConversionPolicy) number(s string) string {
+	if s == "" && p.EmptyStringZero {
+		return "0"
+	}
+	if p.DecimalSeparator != 0 && p.DecimalSeparator != '.' {
+		return strings.Replace(s, string(p.DecimalSeparator), ".", 1)
+	}
+	return s
+}
+
+// toBool converts v to a bool.
+func (p *ConversionPolicy) toBool(v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		if p.StrictNil {
+			return false, errNilConversion
+		}
+	case string:
+		if v == "" && p.EmptyStringZero {
+			return false, nil
+		}
+		return p.parseBool(v)
+	}
+	return cast.ToBoolE(v)
+}
+
+// toString converts v to a string.
+func (p *ConversionPolicy) toString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		if p.StrictNil {
+			return "", errNilConversion
+		}
+	case bool:
+		return p.formatBool(v), nil
+	case float32:
+		return p.formatFloat(float64(v), 32), nil
+	case float64:
+		return p.formatFloat(v, 64), nil
+	}
+	return cast.ToStringE(v)
+}
+
+// toNumber prepares v for a conversion to a number by cast.
+func (p *ConversionPolicy) toNumber(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		if p.StrictNil {
+			return nil, errNilConversion
+		}
+	case string:
+		return p.number(v), nil
+	}
+	return v, nil
+}
+
+func (c *converter) canIconv(typ *itype, expected *itype) bool {
 	if typ.assignableTo(expected) {
 		return true
 	}
@@ -20,7 +213,10 @@ func canIconv(typ *itype, expected *itype) bool {
 		return true
 	}
 	ertype := expected.rtype
-	_, err := rconv(reflect.New(typ.rtype).Elem(), ertype)
+	if c.lookup(typ.rtype, ertype) != nil {
+		return true
+	}
+	_, err := c.rconv(reflect.New(typ.rtype).Elem(), ertype)
 	return err == nil
 }
 
@@ -29,11 +225,87 @@ func canIconvBool(t *itype) bool {
 	return typ.Kind() == reflect.Bool || isNumber(typ) || isString(typ) || isInterface(t)
 }
 
-func canRconvBool(t reflect.Type) bool {
-	return t.Kind() == reflect.Bool || t.Kind() == reflect.Interface || isNumber(t) || isString(t)
+// isCond returns true if the value of n can be used as a condition.
+// In strict mode, only booleans are accepted, otherwise any value with
+// a truthiness.
+func (interp *Interpreter) isCond(n *node) bool {
+	t := n.typ
+	if interp.strict {
+		return isBool(t)
+	}
+	switch t.cat {
+	case builtinT, binPkgT, srcPkgT:
+		return false
+	}
+	if t.TypeOf() == nil {
+		return false
+	}
+	interp.noteBool(n, "condition")
+	return true
+}
+
+// truthy returns the truthiness of v in the loose dialect:
+//   - values implementing Truthy define their own
+//   - nil values, zero numbers and zero structs are false
+//   - strings are converted to bool according to the conversion policy,
+//     and are false if not convertible
+//   - arrays, slices, maps and channels are true if not empty
+//   - other values, such as non nil pointers and functions, are true
+func (c *converter) truthy(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return c.truthy(v.Elem())
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		if v.IsNil() {
+			return false
+		}
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case Truthy:
+			return x.Truthy()
+		case valueInterface:
+			return c.truthy(x.value)
+		case constant.Value:
+			return constant.BoolVal(c.rconvConstBool(x))
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() != 0
+	case reflect.String:
+		b, _ := c.rules().toBool(v.String())
+		return b
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		return v.Len() > 0
+	case reflect.Struct:
+		return !v.IsZero()
+	}
+	return true
 }
 
+// rconv converts src to expectedType using the builtin rules only.
 func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	var c *converter
+	return c.rconv(src, expectedType)
+}
+
+// rconv converts src to expectedType.
+func (c *converter) rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
 	if !src.IsValid() {
 		return src, nil
 	}
@@ -41,15 +313,41 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 	if srcType == expectedType {
 		return src, nil
 	}
-	if srcType.Kind() == expectedType.Kind() && srcType.Kind() != reflect.Struct &&
+	if srcType == valueInterfaceType && expectedType.Kind() != reflect.Interface {
+		// Value of an interpreted interface, converted from its dynamic value.
+		if src = src.Interface().(valueInterface).value; !src.IsValid() {
+			src = reflect.New(interf).Elem()
+		}
+		return c.rconv(src, expectedType)
+	}
+	dyn := src
+	if dyn.Kind() == reflect.Interface && !dyn.IsNil() {
+		dyn = dyn.Elem()
+	}
+	if fn := c.lookup(dyn.Type(), expectedType); fn != nil {
+		dest := reflect.New(expectedType).Elem()
+		fn(dyn, dest)
+		return dest, nil
+	}
+	if fn := c.coercion(dyn.Type(), expectedType); fn != nil {
+		return fn(c, dyn, expectedType)
+	}
+	if srcType.Kind() == expectedType.Kind() && srcType.Kind() != reflect.Struct && srcType.ConvertibleTo(expectedType) &&
 		(srcType.PkgPath() != expectedType.PkgPath() || srcType.Name() != expectedType.Name()) {
 		// type def from existing type
 		return src.Convert(expectedType), nil
 	}
 	value := src.Interface()
+	p := c.rules()
+	if isNumber(expectedType) {
+		var err error
+		if value, err = p.toNumber(value); err != nil {
+			return src, err
+		}
+	}
 	switch expectedType.Kind() {
 	case reflect.Bool:
-		casted, err := cast.ToBoolE(value)
+		casted, err := p.toBool(value)
 		if err == nil {
 			return reflect.ValueOf(casted), nil
 		} else {
@@ -149,7 +447,7 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 			bytes, err := json.Marshal(value)
 			return reflect.ValueOf(string(bytes)), err
 		default:
-			casted, err := cast.ToStringE(value)
+			casted, err := p.toString(value)
 			if err == nil {
 				return reflect.ValueOf(casted), nil
 			} else {
@@ -170,27 +468,16 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 				return src, err
 			}
 		case reflect.Struct:
-			err := copier.Copy(castedPtrValue.Interface(), value)
-			if err == nil {
-				return castedPtrValue.Elem(), nil
-			} else {
-				return src, err
-			}
+			return c.structToStruct(indirect, expectedType)
 		case reflect.Map:
-			var bytes []byte
-			var err error
-			bytes, err = json.Marshal(indirect.Interface())
-			if err == nil {
-				err = json.Unmarshal(bytes, castedPtrValue.Interface())
-				if err == nil {
-					return castedPtrValue.Elem(), nil
-				}
-			}
-			return src, err
+			return c.mapToStruct(indirect, expectedType)
 		case reflect.Interface:
-			return indirect.Elem().Convert(expectedType), nil
+			if indirect.IsNil() {
+				return reflect.Zero(expectedType), nil
+			}
+			return c.rconv(indirect.Elem(), expectedType)
 		default:
-			return src, errors.New(fmt.Sprintf(""))
+			return src, fmt.Errorf("cannot convert %s to struct %s", kind, expectedType)
 		}
 	case reflect.Map:
 		indirect := rconvToConcrete(reflect.Indirect(src))
@@ -213,18 +500,7 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 		case reflect.Invalid:
 			return reflect.Zero(expectedType), nil
 		case reflect.Struct:
-			srcBytes, err := json.Marshal(src.Interface())
-			if err != nil {
-				return src, err
-			} else {
-				castedPtrValue := reflect.New(expectedType)
-				err := json.Unmarshal(srcBytes, castedPtrValue.Interface())
-				if err == nil {
-					return castedPtrValue.Elem(), nil
-				} else {
-					return src, err
-				}
-			}
+			return c.structToMap(indirect, expectedType)
 		default:
 			return src, nil
 		}
@@ -240,11 +516,11 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 			v := indirect.MapIndex(k)
 			var kcasted, vcasted reflect.Value
 			var err error
-			kcasted, err = rconv(k, ktype)
+			kcasted, err = c.rconv(k, ktype)
 			if err != nil {
 				return src, err
 			}
-			vcasted, err = rconv(v, vtype)
+			vcasted, err = c.rconv(v, vtype)
 			if err != nil {
 				return src, err
 			}
@@ -253,16 +529,19 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 		return castedValue, nil
 	case reflect.Slice:
 		src = rconvToConcrete(src)
+		if !src.IsValid() {
+			return reflect.Zero(expectedType), nil
+		}
 		srcType = src.Type()
 		if srcType.Kind() == reflect.String {
-			return rconv(reflect.ValueOf([]uint8(src.String())), expectedType)
-		} else if srcType.Kind() != reflect.Slice {
+			return c.rconv(reflect.ValueOf([]uint8(src.String())), expectedType)
+		} else if srcType.Kind() != reflect.Slice && srcType.Kind() != reflect.Array {
 			return src, nil
 		}
 		vtype := expectedType.Elem()
 		castedValue := reflect.MakeSlice(expectedType, src.Len(), src.Cap())
 		for i := 0; i < src.Len(); i++ {
-			vcasted, err := rconv(src.Index(i), vtype)
+			vcasted, err := c.rconv(src.Index(i), vtype)
 			if err == nil {
 				castedValue.Index(i).Set(vcasted)
 			} else {
@@ -270,28 +549,140 @@ func rconv(src reflect.Value, expectedType reflect.Type) (reflect.Value, error)
 			}
 		}
 		return castedValue, nil
+	case reflect.Array:
+		src = rconvToConcrete(src)
+		switch src.Kind() {
+		case reflect.Invalid:
+			return reflect.Zero(expectedType), nil
+		case reflect.Slice, reflect.Array:
+			return c.sequenceToArray(src, expectedType)
+		}
+		return src, errArrayConversion
 	case reflect.Ptr:
-		castedValue, err := rconv(src, expectedType.Elem())
-		casted := castedValue.Interface()
-		if err == nil {
-			castedPtrVal := reflect.New(reflect.TypeOf(casted))
-			castedPtrVal.Elem().Set(castedValue)
-			return castedPtrVal, nil
-		} else {
+		src = rconvToConcrete(src)
+		switch {
+		case !src.IsValid():
+			return reflect.Zero(expectedType), nil
+		case src.Kind() == reflect.Ptr:
+			// A pointer is converted in place, to keep referencing the same value.
+			if src.IsNil() {
+				return reflect.Zero(expectedType), nil
+			}
+			if src.Type().ConvertibleTo(expectedType) {
+				return src.Convert(expectedType), nil
+			}
+			return src, errPointerConversion
+		}
+		// A non pointer value is converted to a new pointed value.
+		castedValue, err := c.rconv(src, expectedType.Elem())
+		if err != nil {
 			return src, err
 		}
+		if !castedValue.Type().AssignableTo(expectedType.Elem()) {
+			return src, fmt.Errorf("%v does not convert to %v", src.Type(), expectedType.Elem())
+		}
+		castedPtrVal := reflect.New(expectedType.Elem())
+		castedPtrVal.Elem().Set(castedValue)
+		return castedPtrVal, nil
+	case reflect.Func:
+		src = rconvToConcrete(src)
+		switch {
+		case !src.IsValid(), src.Kind() == reflect.Func && src.IsNil():
+			return reflect.Zero(expectedType), nil
+		case src.Type() == reflect.PtrTo(rNodeType):
+			// Interpreted function, wrapped at call or assignment.
+			return src, nil
+		case src.Type().ConvertibleTo(expectedType):
+			return src.Convert(expectedType), nil
+		}
+		return src, errFuncConversion
 	default:
 		return src, nil
 	}
 }
 
-func rconvAndSet(dvalue reflect.Value, svalue reflect.Value) error {
+// isCoercible returns true if the type assertions to t convert the dynamic
+// value in the CoerceAssertions mode.
+func isCoercible(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Map, reflect.Slice, reflect.Struct:
+		return true
+	}
+	return isNumber(t) && !isComplex(t)
+}
+
+// coerce converts the dynamic value v to t, for a type assertion in the
+// CoerceAssertions mode. A nil value converts to the zero value of t,
+// unless t is not nullable and the policy is StrictNil.
+func (c *converter) coerce(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !v.IsValid() {
+		if !isNullable(t) && c.rules().StrictNil {
+			return reflect.Zero(t), errNilConversion
+		}
+		return reflect.Zero(t), nil
+	}
+	r, err := c.rconv(v, t)
+	if err == nil && r.Type() != t {
+		err = fmt.Errorf("cannot convert %v to %v", v.Type(), t)
+	}
+	return r, err
+}
+
+// coerceLossless converts the dynamic value v to t as coerce does, and
+// reports whether the conversion preserved the value: converting a basic
+// value back to its type must yield the same value, or the same number for
+// a numeric string, while maps, slices and structs only need to convert.
+// A nil value is not converted.
+func (c *converter) coerceLossless(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	zero := reflect.Zero(t)
+	if !v.IsValid() {
+		return zero, false
+	}
+	r, err := c.coerce(v, t)
+	if err != nil {
+		return zero, false
+	}
+	if !isCoercible(v.Type()) || isComposite(v.Type()) || isComposite(t) {
+		return r, true
+	}
+	if back, err := c.rconv(r, v.Type()); err == nil && back.Interface() == v.Interface() {
+		return r, true
+	}
+	if v.Kind() == reflect.String && isNumber(t) {
+		f0, err0 := c.rconv(v, float64Type)
+		f1, err1 := c.rconv(r, float64Type)
+		if err0 == nil && err1 == nil && f0.Float() == f1.Float() {
+			return r, true
+		}
+	}
+	return zero, false
+}
+
+// isComposite returns true if t is a map, slice or struct type.
+func isComposite(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Struct:
+		return true
+	}
+	return false
+}
+
+// dynamicType returns the type of the value held by v if v is a non nil
+// interface, or the type of v otherwise.
+func dynamicType(v reflect.Value) reflect.Type {
+	if v.Kind() == reflect.Interface && v.Elem().IsValid() {
+		return v.Elem().Type()
+	}
+	return v.Type()
+}
+
+func (c *converter) rconvAndSet(dvalue reflect.Value, svalue reflect.Value) error {
 	tleft := dvalue.Type()
 	tright := svalue.Type()
 	if tright.AssignableTo(tleft) {
 		dvalue.Set(svalue)
 	} else {
-		vright, err := rconv(svalue, tleft)
+		vright, err := c.rconv(svalue, tleft)
 		if err == nil {
 			dvalue.Set(vright)
 		} else {
@@ -301,15 +692,42 @@ func rconvAndSet(dvalue reflect.Value, svalue reflect.Value) error {
 	return nil
 }
 
-func rconvNumber(value reflect.Value) reflect.Value {
+// rconv converts v to type t at node n. A failure is handled according to
+// the interpreter conversion failure policy, see convFailed.
+func (n *node) rconv(v reflect.Value, t reflect.Type) reflect.Value {
+	cv, err := n.interp.conv.rconv(v, t)
+	if err != nil {
+		return n.convFailed(v, t, err)
+	}
+	return cv
+}
+
+// rconvAndSet sets dest to src, converted to the type of dest if necessary.
+func (n *node) rconvAndSet(dest, src reflect.Value) {
+	if err := n.interp.conv.rconvAndSet(dest, src); err != nil {
+		dest.Set(n.convFailed(src, dest.Type(), err))
+	}
+}
+
+// convFailed handles the failed conversion of v to type t at node n,
+// according to the conversion failure policy: it returns the zero value
+// of t in ConversionFailZero mode, and panics with a *ConversionError otherwise.
+func (n *node) convFailed(v reflect.Value, t reflect.Type, err error) reflect.Value {
+	if n.interp.convFailure == ConversionFailZero {
+		return reflect.Zero(t)
+	}
+	panic(n.conversionError(v, t, err))
+}
+
+func (c *converter) rconvNumber(value reflect.Value) reflect.Value {
 	if !value.IsValid() || value.IsZero() {
 		return reflect.ValueOf(0)
 	}
 	if value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
-		return rconvNumber(value.Elem())
+		return c.rconvNumber(value.Elem())
 	}
 	if isString(value.Type()) {
-		val := value.Interface().(string)
+		val := c.rules().number(value.Interface().(string))
 		var num interface{}
 		var err error
 		if strings.Index(val, ".") > -1 {
@@ -339,17 +757,22 @@ func rconvNumber(value reflect.Value) reflect.Value {
 	}
 }
 
-func rconvConst(val constant.Value, kind constant.Kind) constant.Value {
+func (c *converter) rconvConst(val constant.Value, kind constant.Kind) constant.Value {
+	p := c.rules()
 	v := constToInterface(val)
 	switch kind {
 	case constant.Bool:
-		return constant.MakeBool(cast.ToBool(v))
+		b, _ := p.toBool(v)
+		return constant.MakeBool(b)
 	case constant.String:
-		return constant.MakeString(cast.ToString(v))
+		s, _ := p.toString(v)
+		return constant.MakeString(s)
 	case constant.Int:
-		return constant.MakeInt64(cast.ToInt64(v))
+		n, _ := p.toNumber(v)
+		return constant.MakeInt64(cast.ToInt64(n))
 	case constant.Float:
-		return constant.MakeFloat64(cast.ToFloat64(v))
+		n, _ := p.toNumber(v)
+		return constant.MakeFloat64(cast.ToFloat64(n))
 	}
 	return nil
 }
@@ -379,13 +802,13 @@ func rconvToConcrete(value reflect.Value) reflect.Value {
 	}
 }
 
-func rconvConstNumber(val constant.Value) (c constant.Value) {
+func (c *converter) rconvConstNumber(val constant.Value) (cv constant.Value) {
 	v := constToInterface(val)
 	switch reflect.ValueOf(v).Kind() {
 	case reflect.Bool:
-		c = constant.MakeInt64(cast.ToInt64(v))
+		cv = constant.MakeInt64(cast.ToInt64(v))
 	case reflect.String:
-		vstr := v.(string)
+		vstr := c.rules().number(v.(string))
 		if strings.Index(vstr, ".") > -1 {
 			var num float64
 			var err error
@@ -393,7 +816,7 @@ func rconvConstNumber(val constant.Value) (c constant.Value) {
 			if err != nil {
 				panic(err)
 			}
-			c = constant.MakeFloat64(num)
+			cv = constant.MakeFloat64(num)
 		} else {
 			var num int64
 			var err error
@@ -401,93 +824,104 @@ func rconvConstNumber(val constant.Value) (c constant.Value) {
 			if err != nil {
 				panic(err)
 			}
-			c = constant.MakeInt64(num)
+			cv = constant.MakeInt64(num)
 		}
 	case reflect.Int64:
-		c = constant.MakeInt64(cast.ToInt64(v))
+		cv = constant.MakeInt64(cast.ToInt64(v))
 	case reflect.Float64:
-		c = constant.MakeFloat64(cast.ToFloat64(v))
+		cv = constant.MakeFloat64(cast.ToFloat64(v))
 	}
 	return
 }
 
-func rconvConstInt(value constant.Value) constant.Value {
-	v := rconvConstNumber(value)
+func (c *converter) rconvConstInt(value constant.Value) constant.Value {
+	v := c.rconvConstNumber(value)
 	if v.Kind() == constant.Float {
 		return constant.MakeInt64(cast.ToInt64(constToInterface(v)))
 	}
 	return v
 }
 
-func rconvConstBool(value constant.Value) constant.Value {
-	return constant.MakeBool(cast.ToBool(constToInterface(value)))
+func (c *converter) rconvConstBool(value constant.Value) constant.Value {
+	b, _ := c.rules().toBool(constToInterface(value))
+	return constant.MakeBool(b)
 }
 
-func rconvConstString(value constant.Value) constant.Value {
-	return constant.MakeString(cast.ToString(constToInterface(value)))
+func (c *converter) rconvConstString(value constant.Value) constant.Value {
+	s, _ := c.rules().toString(constToInterface(value))
+	return constant.MakeString(s)
 }
 
-func rconvToString(val reflect.Value) string {
+func (c *converter) rconvToString(val reflect.Value) string {
 	if !val.IsValid() {
 		return ""
 	}
-	return cast.ToString(val.Interface())
+	s, _ := c.rules().toString(val.Interface())
+	return s
 }
 
-func rconvToBool(val reflect.Value) bool {
+func (c *converter) rconvToBool(val reflect.Value) bool {
 	if !val.IsValid() {
 		return false
 	}
-	return cast.ToBool(val.Interface())
+	b, _ := c.rules().toBool(val.Interface())
+	return b
 }
 
-func rconvToInt(val reflect.Value) int {
+func (c *converter) rconvToInt(val reflect.Value) int {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToInt(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToInt(v)
 }
 
-func rconvToUint(val reflect.Value) uint {
+func (c *converter) rconvToUint(val reflect.Value) uint {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToUint(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToUint(v)
 }
 
-func rconvToInt64(val reflect.Value) int64 {
+func (c *converter) rconvToInt64(val reflect.Value) int64 {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToInt64(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToInt64(v)
 }
 
-func rconvToUint64(val reflect.Value) uint64 {
+func (c *converter) rconvToUint64(val reflect.Value) uint64 {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToUint64(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToUint64(v)
 }
 
-func rconvToFloat32(val reflect.Value) float32 {
+func (c *converter) rconvToFloat32(val reflect.Value) float32 {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToFloat32(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToFloat32(v)
 }
 
-func rconvToFloat64(val reflect.Value) float64 {
+func (c *converter) rconvToFloat64(val reflect.Value) float64 {
 	if !val.IsValid() {
 		return 0
 	}
-	return cast.ToFloat64(val.Interface())
+	v, _ := c.rules().toNumber(val.Interface())
+	return cast.ToFloat64(v)
 }
 
-func rconvToNil(v reflect.Value) bool {
+func (c *converter) rconvToNil(v reflect.Value) bool {
+	strictNil := c.rules().StrictNil
 	if isNullable(v.Type()) {
-		return v.IsNil() || v.Kind() == reflect.Interface && v.Elem().IsZero()
+		return v.IsNil() || !strictNil && v.Kind() == reflect.Interface && v.Elem().IsZero()
 	} else {
-		if v.IsZero() {
+		if !strictNil && v.IsZero() {
 			return true
 		} else {
 			return false
@@ -495,148 +929,422 @@ func rconvToNil(v reflect.Value) bool {
 	}
 }
 
-func compare(val0, val1 interface{}, op string) (value bool, err error) {
-	return rcompare(reflect.ValueOf(val0), reflect.ValueOf(val1), op)
+// Dynamic operators.
+//
+// In the loose dialect, the operators applied to interface operands are
+// resolved at runtime on the dynamic values, following the promotion table
+// below. Operands are first converted according to their dynamic kind:
+//
+//	nil             int 0, or a failure with ConversionPolicy.StrictNil
+//	bool            int 0 or 1
+//	numeric string  int64, or uint64 or float64 if it does not fit or is not integral
+//	other string    a failure, except for + which concatenates it with the
+//	                other operand converted to string, as it does for two strings
+//
+// Then both operands are promoted to a common representation:
+//
+//	complex and any number   complex128: +, -, *, /, == and != only
+//	float and any number     float64, or int64 by truncation for %, &, |, ^, &^, << and >>
+//	int and int or uint      int64
+//	uint and uint            uint64
+//
+// The result has the dynamic type of the operands if they are identical and
+// fit the representation, and the promoted type otherwise. The result of a
+// shift has the type of its left operand, and the shift count must not be
+// negative. An integer division by zero is a runtime error.
+//
+// Comparisons follow the same promotion, with the following additions: an
+// operand is first converted to the type of the other one if a convert hook
+// or a builtin coercion applies, e.g. "1s" compared to a time.Duration; nil
+// is only equal to nil values, regardless of StrictNil, to keep Go semantics
+// for interfaces; a bool operand is compared to the other one converted to
+// bool, == and != only; a string is
+// compared to a number numerically if it is numeric, and as a string
+// otherwise; other values are compared with Go semantics, == and != only.
+
+// dynOperands holds the operands of a dynamic operator, converted to the
+// representation of kind, and the type of the result.
+type dynOperands struct {
+	kind   reflect.Kind // String, Bool, Int64, Uint64, Float64, Complex128 or Interface
+	typ    reflect.Type // type of the result
+	x0, x1 reflect.Value
 }
 
-func rcompare(val0, val1 reflect.Value, op string) (value bool, err error) {
-	if !val0.IsValid() || !val1.IsValid() {
-		if !val0.IsValid() && !val1.IsValid() && op == "==" {
-			return true, nil
-		} else if (val0.IsValid() || val1.IsValid()) && op == "!=" {
-			return true, nil
-		} else {
-			return false, nil
+// integerOps lists the operators which only apply to integers.
+var integerOps = map[token.Token]bool{
+	token.REM: true, token.AND: true, token.OR: true, token.XOR: true,
+	token.AND_NOT: true, token.SHL: true, token.SHR: true,
+}
+
+var (
+	int64Type      = reflect.TypeOf(int64(0))
+	uint64Type     = reflect.TypeOf(uint64(0))
+	float64Type    = reflect.TypeOf(float64(0))
+	complex128Type = reflect.TypeOf(complex128(0))
+	stringType     = reflect.TypeOf("")
+	boolType       = reflect.TypeOf(false)
+)
+
+// numeric returns the number represented by the dynamic value v.
+func (c *converter) numeric(v reflect.Value) (reflect.Value, error) {
+	if !v.IsValid() {
+		if c.rules().StrictNil {
+			return v, errNilConversion
 		}
-	} else if val0.Kind() == reflect.String || (val0.Kind() == reflect.Interface || val0.Kind() == reflect.Ptr) && val0.Elem().Kind() == reflect.String {
-		value, err = compareString(rconvToString(val0), rconvToString(val1), op)
-	} else if val0.Kind() == reflect.Bool || (val0.Kind() == reflect.Interface || val0.Kind() == reflect.Ptr) && val0.Elem().Kind() == reflect.Bool {
-		value, err = compareBool(rconvToBool(val0), rconvToBool(val1), op)
-	} else {
-		val0 = rconvNumber(val0)
-		val1 = rconvNumber(val1)
-		typ0 := val0.Type()
-		typ1 := val1.Type()
-		if isNumber(typ0) && isNumber(typ1) {
-			switch {
-			case isUint(typ0):
-				switch {
-				case isUint(typ1):
-					value, err = compareUint(val0.Uint(), val1.Uint(), op)
-				case isInt(typ1):
-					value, err = compareUint(val0.Uint(), uint64(val1.Int()), op)
-				case isFloat(typ1):
-					value, err = compareUint(val0.Uint(), uint64(val1.Float()), op)
-				}
-			case isInt(typ0):
-				switch {
-				case isUint(typ1):
-					value, err = compareInt(val0.Int(), int64(val1.Uint()), op)
-				case isInt(typ1):
-					value, err = compareInt(val0.Int(), val1.Int(), op)
-				case isFloat(typ1):
-					value, err = compareInt(val0.Int(), int64(val1.Float()), op)
-				}
-			case isFloat(typ0):
-				switch {
-				case isUint(typ1):
-					value, err = compareFloat(val0.Float(), float64(val1.Uint()), op)
-				case isInt(typ1):
-					value, err = compareFloat(val0.Float(), float64(val1.Int()), op)
-				case isFloat(typ1):
-					value, err = compareFloat(val0.Float(), float64(val1.Float()), op)
-				}
-			}
-		} else {
-			err = fmt.Errorf("type %s doesn't support %s operator", typ0.String(), op)
+		return reflect.ValueOf(0), nil
+	}
+	switch t := v.Type(); {
+	case isNumber(t):
+		return v, nil
+	case isBoolean(t):
+		if v.Bool() {
+			return reflect.ValueOf(1), nil
+		}
+		return reflect.ValueOf(0), nil
+	case isString(t):
+		s := c.rules().number(v.String())
+		if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return reflect.ValueOf(i), nil
+		}
+		if u, err := strconv.ParseUint(s, 0, 64); err == nil {
+			return reflect.ValueOf(u), nil
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return reflect.ValueOf(f), nil
 		}
+		return v, fmt.Errorf("%q is not a number", v.String())
 	}
-	return
+	return v, fmt.Errorf("%v is not a number", v.Type())
 }
 
-func compareString(v0 string, v1 string, op string) (bool, error) {
-	switch op {
-	case "==":
-		return v0 == v1, nil
-	case ">":
-		return v0 > v1, nil
-	case ">=":
-		return v0 >= v1, nil
-	case "<":
-		return v0 < v1, nil
-	case "<=":
-		return v0 <= v1, nil
-	case "!=":
-		return v0 != v1, nil
-	default:
-		return false, fmt.Errorf("unsupported comparison operator %s for string", op)
+// isNumeric returns true if the dynamic value v represents a number.
+func (c *converter) isNumeric(v reflect.Value) bool {
+	_, err := c.numeric(v)
+	return err == nil
+}
+
+// numericKind returns the representation of the numeric type t.
+func numericKind(t reflect.Type) reflect.Kind {
+	switch {
+	case isUint(t):
+		return reflect.Uint64
+	case isInt(t):
+		return reflect.Int64
+	case isFloat(t):
+		return reflect.Float64
 	}
+	return reflect.Complex128
 }
 
-func compareBool(v0 bool, v1 bool, op string) (bool, error) {
-	switch op {
-	case "==":
-		return v0 == v1, nil
-	case "!=":
-		return v0 != v1, nil
-	default:
-		return false, fmt.Errorf("unsupported comparison operator %s for bool", op)
+// asKind returns the number v in the representation of kind.
+func asKind(v reflect.Value, kind reflect.Kind) reflect.Value {
+	k := numericKind(v.Type())
+	switch kind {
+	case reflect.Int64:
+		switch k {
+		case reflect.Uint64:
+			return reflect.ValueOf(int64(v.Uint()))
+		case reflect.Float64:
+			return reflect.ValueOf(int64(v.Float()))
+		}
+		return reflect.ValueOf(v.Int())
+	case reflect.Uint64:
+		return reflect.ValueOf(v.Uint())
+	case reflect.Float64:
+		switch k {
+		case reflect.Int64:
+			return reflect.ValueOf(float64(v.Int()))
+		case reflect.Uint64:
+			return reflect.ValueOf(float64(v.Uint()))
+		}
+		return reflect.ValueOf(v.Float())
 	}
+	switch k {
+	case reflect.Int64:
+		return reflect.ValueOf(complex(float64(v.Int()), 0))
+	case reflect.Uint64:
+		return reflect.ValueOf(complex(float64(v.Uint()), 0))
+	case reflect.Float64:
+		return reflect.ValueOf(complex(v.Float(), 0))
+	}
+	return reflect.ValueOf(v.Complex())
 }
 
-func compareInt(v0 int64, v1 int64, op string) (bool, error) {
-	switch op {
-	case "==":
-		return v0 == v1, nil
-	case ">":
-		return v0 > v1, nil
-	case ">=":
-		return v0 >= v1, nil
-	case "<":
-		return v0 < v1, nil
-	case "<=":
-		return v0 <= v1, nil
-	case "!=":
-		return v0 != v1, nil
+// resultType returns the type of the result of an operation on values of
+// types t0 and t1 in the representation of kind, and of type def otherwise.
+func resultType(t0, t1 reflect.Type, kind reflect.Kind, def reflect.Type) reflect.Type {
+	if t0 == t1 && (kind == reflect.String || kind == reflect.Bool || numericKind(t0) == kind) {
+		return t0
+	}
+	return def
+}
+
+// promote converts the dynamic operands of the binary operator op to a
+// common representation. On failure, an *operandError is returned.
+func (c *converter) promote(op token.Token, v0, v1 reflect.Value) (o dynOperands, err error) {
+	v0, v1 = concreteValue(v0), concreteValue(v1)
+	if op == token.ADD && (isStringValue(v0) && isStringValue(v1) ||
+		isStringValue(v0) && !c.isNumeric(v0) || isStringValue(v1) && !c.isNumeric(v1)) {
+		return c.promoteString(v0, v1)
+	}
+
+	n0, err := c.numeric(v0)
+	if err != nil {
+		return o, newOperandError(0, v0, v1, fmt.Errorf("only numbers support %v operator", op))
+	}
+	n1, err := c.numeric(v1)
+	if err != nil {
+		return o, newOperandError(1, v0, v1, fmt.Errorf("only numbers support %v operator", op))
+	}
+	t0, t1 := n0.Type(), n1.Type()
+	k0, k1 := numericKind(t0), numericKind(t1)
+
+	switch {
+	case op == token.SHL || op == token.SHR:
+		if k1 == reflect.Complex128 {
+			return o, newOperandError(1, v0, v1, errors.New("shift count must be an integer"))
+		}
+		o.kind, o.typ = k0, t0
+		if k0 == reflect.Float64 {
+			o.kind, o.typ = reflect.Int64, int64Type
+		}
+		if k0 == reflect.Uint64 && k1 == reflect.Uint64 && n1.Uint() > math.MaxInt64 {
+			n1 = reflect.ValueOf(uint64(math.MaxInt64))
+		}
+		o.x0, o.x1 = asKind(n0, o.kind), asKind(n1, reflect.Int64)
+		if o.kind == reflect.Complex128 {
+			return o, newOperandError(0, v0, v1, fmt.Errorf("only integers support %v operator", op))
+		}
+		return o, nil
+	case k0 == reflect.Complex128 || k1 == reflect.Complex128:
+		o.kind = reflect.Complex128
+		if integerOps[op] {
+			if k1 == reflect.Complex128 {
+				return o, newOperandError(1, v0, v1, fmt.Errorf("only integers support %v operator", op))
+			}
+			return o, newOperandError(0, v0, v1, fmt.Errorf("only integers support %v operator", op))
+		}
+	case k0 == reflect.Float64 || k1 == reflect.Float64:
+		o.kind = reflect.Float64
+		if integerOps[op] {
+			o.kind = reflect.Int64
+		}
+	case k0 == reflect.Int64 || k1 == reflect.Int64:
+		o.kind = reflect.Int64
 	default:
-		return false, fmt.Errorf("unsupported comparison operator %s for integer", op)
+		o.kind = reflect.Uint64
 	}
+	o.typ = resultType(t0, t1, o.kind, kindType(o.kind))
+	o.x0, o.x1 = asKind(n0, o.kind), asKind(n1, o.kind)
+	return o, nil
 }
 
-func compareUint(v0 uint64, v1 uint64, op string) (bool, error) {
-	switch op {
-	case "==":
-		return v0 == v1, nil
-	case ">":
-		return v0 > v1, nil
-	case ">=":
-		return v0 >= v1, nil
-	case "<":
-		return v0 < v1, nil
-	case "<=":
-		return v0 <= v1, nil
-	case "!=":
-		return v0 != v1, nil
+// promoteUnary converts the dynamic operand of the unary operator op.
+func (c *converter) promoteUnary(op token.Token, v reflect.Value) (o dynOperands, err error) {
+	v = concreteValue(v)
+	n0, err := c.numeric(v)
+	if err != nil {
+		return o, newOperandError(0, v, reflect.Value{}, fmt.Errorf("only numbers support %v operator", op))
+	}
+	t := n0.Type()
+	o.kind = numericKind(t)
+	if op == token.XOR {
+		switch o.kind {
+		case reflect.Float64:
+			o.kind = reflect.Int64
+		case reflect.Complex128:
+			return o, newOperandError(0, v, reflect.Value{}, fmt.Errorf("only integers support %v operator", op))
+		}
+	}
+	o.typ = resultType(t, t, o.kind, kindType(o.kind))
+	o.x0 = asKind(n0, o.kind)
+	return o, nil
+}
+
+// promoteString converts the dynamic operands v0 and v1 to strings.
+func (c *converter) promoteString(v0, v1 reflect.Value) (o dynOperands, err error) {
+	s0, err := c.rules().toString(valueInterfaceOf(v0))
+	if err != nil {
+		return o, &operandError{0, v0, stringType, err}
+	}
+	s1, err := c.rules().toString(valueInterfaceOf(v1))
+	if err != nil {
+		return o, &operandError{1, v1, stringType, err}
+	}
+	o.kind = reflect.String
+	o.typ = stringType
+	if v0.IsValid() && v1.IsValid() {
+		o.typ = resultType(v0.Type(), v1.Type(), reflect.String, stringType)
+	}
+	o.x0, o.x1 = reflect.ValueOf(s0), reflect.ValueOf(s1)
+	return o, nil
+}
+
+// promoteComparison converts the dynamic operands of the comparison
+// operator op to a common representation.
+func (c *converter) promoteComparison(op token.Token, v0, v1 reflect.Value) (o dynOperands, err error) {
+	v0, v1 = concreteValue(v0), concreteValue(v1)
+	equality := op == token.EQL || op == token.NEQ
+
+	if v0.IsValid() && v1.IsValid() && v0.Type() != v1.Type() {
+		// Convert an operand to the type of the other one with a convert hook,
+		// or a builtin coercion.
+		if fn := c.lookup(v1.Type(), v0.Type()); fn != nil {
+			v := reflect.New(v0.Type()).Elem()
+			fn(v1, v)
+			return c.promoteComparison(op, v0, v)
+		}
+		if fn := c.lookup(v0.Type(), v1.Type()); fn != nil {
+			v := reflect.New(v1.Type()).Elem()
+			fn(v0, v)
+			return c.promoteComparison(op, v, v1)
+		}
+		if fn := c.coercion(v1.Type(), v0.Type()); fn != nil {
+			if v, err := fn(c, v1, v0.Type()); err == nil {
+				return c.promoteComparison(op, v0, v)
+			}
+		}
+		if fn := c.coercion(v0.Type(), v1.Type()); fn != nil {
+			if v, err := fn(c, v0, v1.Type()); err == nil {
+				return c.promoteComparison(op, v, v1)
+			}
+		}
+	}
+
+	switch {
+	case equality && (!v0.IsValid() || !v1.IsValid()):
+		// Compare the nil status of both operands.
+		o.kind, o.typ = reflect.Bool, boolType
+		o.x0, o.x1 = reflect.ValueOf(isNilValue(v0)), reflect.ValueOf(isNilValue(v1))
+		return o, nil
+	case isBoolValue(v0) || isBoolValue(v1):
+		if !equality {
+			return o, fmt.Errorf("invalid operation: operator %v not defined on bool", op)
+		}
+		b0, err0 := c.rules().toBool(valueInterfaceOf(v0))
+		b1, err1 := c.rules().toBool(valueInterfaceOf(v1))
+		if err0 == nil && err1 == nil {
+			o.kind, o.typ = reflect.Bool, boolType
+			o.x0, o.x1 = reflect.ValueOf(b0), reflect.ValueOf(b1)
+			return o, nil
+		}
+		return c.promoteString(v0, v1)
+	case isStringValue(v0) && isStringValue(v1):
+		return c.promoteString(v0, v1)
+	case isStringValue(v0) || isStringValue(v1):
+		if !c.isNumeric(v0) || !c.isNumeric(v1) {
+			return c.promoteString(v0, v1)
+		}
+	case v0.IsValid() && v1.IsValid() && (!isNumber(v0.Type()) || !isNumber(v1.Type())):
+		// Compare other values as interfaces, with Go semantics.
+		if !v0.Type().Comparable() {
+			return o, fmt.Errorf("comparing uncomparable type %v", v0.Type())
+		}
+		if !v1.Type().Comparable() {
+			return o, fmt.Errorf("comparing uncomparable type %v", v1.Type())
+		}
+		o.kind, o.typ = reflect.Interface, v0.Type()
+		o.x0, o.x1 = v0, v1
+		return o, nil
+	}
+	return c.promote(op, v0, v1)
+}
+
+// isNilValue returns true if the dynamic value v is nil.
+func isNilValue(v reflect.Value) bool {
+	return !v.IsValid() || isNullable(v.Type()) && v.IsNil()
+}
+
+// kindType returns the type of the representation kind.
+func kindType(kind reflect.Kind) reflect.Type {
+	switch kind {
+	case reflect.Int64:
+		return int64Type
+	case reflect.Uint64:
+		return uint64Type
+	case reflect.Float64:
+		return float64Type
+	}
+	return complex128Type
+}
+
+func isStringValue(v reflect.Value) bool { return v.IsValid() && v.Kind() == reflect.String }
+
+func isBoolValue(v reflect.Value) bool { return v.IsValid() && v.Kind() == reflect.Bool }
+
+// valueInterfaceOf returns the interface held by v, or nil if v is not valid.
+func valueInterfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// operandError is the failed conversion of the operand at index of a
+// dynamic operator.
+type operandError struct {
+	index   int
+	operand reflect.Value
+	target  reflect.Type
+	err     error
+}
+
+func (e *operandError) Error() string { return e.err.Error() }
+
+// newOperandError returns the failed conversion to a number of the operand at
+// index in v0, v1, with the type of the other operand as target if it is a number.
+func newOperandError(index int, v0, v1 reflect.Value, err error) *operandError {
+	bad, other := v0, v1
+	if index == 1 {
+		bad, other = v1, v0
+	}
+	target := reflect.TypeOf(0)
+	if other.IsValid() && isNumber(other.Type()) {
+		target = other.Type()
+	}
+	return &operandError{index, bad, target, err}
+}
+
+// promote converts the dynamic operands of the operator n, as by
+// converter.promote or converter.promoteComparison. A failed conversion
+// is handled by convFailed: in ConversionFailZero mode, the operand is
+// replaced by the zero value of the target type.
+func (n *node) promote(op token.Token, v0, v1 reflect.Value) dynOperands {
+	var o dynOperands
+	var err error
+	if isComparisonToken(op) {
+		o, err = n.interp.conv.promoteComparison(op, v0, v1)
+	} else {
+		o, err = n.interp.conv.promote(op, v0, v1)
+	}
+	switch e := err.(type) {
+	case nil:
+		return o
+	case *operandError:
+		z := n.convFailed(e.operand, e.target, e.err)
+		if e.index == 0 {
+			return n.promote(op, z, v1)
+		}
+		return n.promote(op, v0, z)
 	default:
-		return false, fmt.Errorf("unsupported comparison operator %s for unsigned integer", op)
+		panic(n.runErrorf("%v", err))
+	}
+}
+
+// promoteUnary converts the dynamic operand of the unary operator n, as by
+// converter.promoteUnary. A failed conversion is handled as in promote.
+func (n *node) promoteUnary(op token.Token, v reflect.Value) dynOperands {
+	o, err := n.interp.conv.promoteUnary(op, v)
+	if e, ok := err.(*operandError); ok {
+		return n.promoteUnary(op, n.convFailed(e.operand, e.target, e.err))
 	}
+	return o
 }
 
-func compareFloat(v0 float64, v1 float64, op string) (bool, error) {
+func isComparisonToken(op token.Token) bool {
 	switch op {
-	case "==":
-		return v0 == v1, nil
-	case ">":
-		return v0 > v1, nil
-	case ">=":
-		return v0 >= v1, nil
-	case "<":
-		return v0 < v1, nil
-	case "<=":
-		return v0 <= v1, nil
-	case "!=":
-		return v0 != v1, nil
-	default:
-		return false, fmt.Errorf("unknown comparison operator %s for float", op)
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
 	}
+	return false
 }
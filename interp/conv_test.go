@@ -1,9 +1,13 @@
 package interp
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
 )
 
 var (
@@ -62,3 +66,372 @@ func dorconv(src reflect.Value, expectedType reflect.Type) {
 	}
 	fmt.Printf("%v %#v => %v : %#v\n", src.Type().String(), src, result.Type().String(), result)
 }
+
+func TestConversionPolicy(t *testing.T) {
+	words := ConversionPolicy{TrueStrings: []string{"yes", "on"}, FalseStrings: []string{"no", "off"}}
+	locale := ConversionPolicy{DecimalSeparator: ',', FloatFormat: 'f', FloatPrecision: 2}
+
+	tests := []struct {
+		desc   string
+		policy ConversionPolicy
+		src    interface{}
+		typ    reflect.Type
+		res    interface{}
+		err    bool
+	}{
+		{desc: "default bool", src: "true", typ: typeofBool, res: true},
+		{desc: "default bool word", src: "yes", typ: typeofBool, err: true},
+		{desc: "bool word", policy: words, src: "Yes", typ: typeofBool, res: true},
+		{desc: "bool word false", policy: words, src: "OFF", typ: typeofBool, res: false},
+		{desc: "bool word invalid", policy: words, src: "true", typ: typeofBool, err: true},
+		{desc: "bool to word", policy: words, src: false, typ: typeofString, res: "no"},
+		{desc: "default float", src: 1.5, typ: typeofString, res: "1.5"},
+		{desc: "float precision", policy: locale, src: 1.5, typ: typeofString, res: "1,50"},
+		{desc: "float exponent", policy: ConversionPolicy{FloatFormat: 'e', FloatPrecision: 1}, src: 1500.0, typ: typeofString, res: "1.5e+03"},
+		{desc: "locale decimal", policy: locale, src: "2,25", typ: typeofFloat64, res: 2.25},
+		{desc: "default empty string", src: "", typ: typeofInt, err: true},
+		{desc: "empty string int", policy: ConversionPolicy{EmptyStringZero: true}, src: "", typ: typeofInt, res: 0},
+		{desc: "empty string bool", policy: ConversionPolicy{EmptyStringZero: true}, src: "", typ: typeofBool, res: false},
+		{desc: "default nil", src: nil, typ: typeofInt, res: 0},
+		{desc: "strict nil", policy: ConversionPolicy{StrictNil: true}, src: nil, typ: typeofInt, err: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			c := newConverter(&hooks{}, test.policy)
+			src := reflect.ValueOf(&test.src).Elem()
+			res, err := c.rconv(src, test.typ)
+			if test.err {
+				if err == nil {
+					t.Fatalf("got %v, want error", res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Interface() != test.res {
+				t.Errorf("got %#v, want %#v", res.Interface(), test.res)
+			}
+		})
+	}
+}
+
+func TestConversionPolicyNil(t *testing.T) {
+	var v interface{} = 0
+	zero := reflect.ValueOf(&v).Elem()
+	if c := newConverter(&hooks{}, ConversionPolicy{}); !c.rconvToNil(zero) {
+		t.Error("zero value should compare equal to nil by default")
+	}
+	if c := newConverter(&hooks{}, ConversionPolicy{StrictNil: true}); c.rconvToNil(zero) {
+		t.Error("zero value should not compare equal to nil with StrictNil")
+	}
+}
+
+type Audit struct {
+	ID      int       `yaegi:"id" json:"audit_id"`
+	Created time.Time `json:"created"`
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type Account struct {
+	*Audit
+	Name    string            `json:"name"`
+	Age     int               `json:"age,omitempty"`
+	Tags    []string          `json:"tags"`
+	Pos     [2]float64        `json:"pos"`
+	Addr    *Address          `json:"addr"`
+	Meta    map[string]string `json:"meta"`
+	Secret  string            `json:"-"`
+	private int
+}
+
+type AccountView struct {
+	ID   int64
+	NAME string
+	Age  string
+	Addr Address
+}
+
+type Tree struct {
+	*Tree
+	Value int
+}
+
+type Left struct{ X, L int }
+
+type Right struct{ X, R int }
+
+type TaggedRight struct {
+	X int `json:"X"`
+}
+
+type Both struct {
+	Left
+	Right
+}
+
+type BothTagged struct {
+	Left
+	TaggedRight
+}
+
+func TestStructConversion(t *testing.T) {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	account := Account{
+		Audit: &Audit{ID: 7, Created: created},
+		Name:  "bob", Age: 30, Tags: []string{"a"}, Pos: [2]float64{1, 2},
+		Addr: &Address{City: "Paris"}, Secret: "s", private: 1,
+	}
+
+	tests := []struct {
+		desc string
+		src  interface{}
+		typ  reflect.Type
+		res  interface{}
+		err  string
+	}{
+		{
+			desc: "map to struct",
+			src: map[string]interface{}{
+				"id": "7", "created": "2022-01-02T03:04:05Z", "NAME": "bob", "age": 30.0, "tags": []interface{}{"a"},
+				"pos": []interface{}{1, "2", 3}, "addr": map[string]interface{}{"city": "Paris"}, "Secret": "s", "other": 1,
+			},
+			typ: reflect.TypeOf(Account{}),
+			res: Account{Audit: &Audit{ID: 7, Created: created}, Name: "bob", Age: 30, Tags: []string{"a"}, Pos: [2]float64{1, 2}, Addr: &Address{City: "Paris"}},
+		},
+		{
+			desc: "struct to map",
+			src:  account,
+			typ:  reflect.TypeOf(map[string]interface{}{}),
+			res: map[string]interface{}{
+				"id": 7, "created": created, "name": "bob", "age": 30, "tags": []string{"a"}, "pos": [2]float64{1, 2},
+				"addr": &Address{City: "Paris"}, "meta": map[string]string(nil),
+			},
+		},
+		{
+			desc: "struct to map omitempty",
+			src:  Account{Name: "bob"},
+			typ:  reflect.TypeOf(map[string]interface{}{}),
+			res: map[string]interface{}{
+				"name": "bob", "tags": []string(nil), "pos": [2]float64{}, "addr": (*Address)(nil), "meta": map[string]string(nil),
+			},
+		},
+		{
+			desc: "struct to struct",
+			src:  &account,
+			typ:  reflect.TypeOf(AccountView{}),
+			res:  AccountView{ID: 7, NAME: "bob", Age: "30", Addr: Address{City: "Paris"}},
+		},
+		{
+			desc: "self embedding",
+			src:  Tree{Tree: &Tree{Value: 1}, Value: 2},
+			typ:  reflect.TypeOf(map[string]interface{}{}),
+			res:  map[string]interface{}{"Value": 2},
+		},
+		{
+			desc: "ambiguous fields",
+			src:  Both{Left{X: 1, L: 2}, Right{X: 3, R: 4}},
+			typ:  reflect.TypeOf(map[string]interface{}{}),
+			res:  map[string]interface{}{"L": 2, "R": 4},
+		},
+		{
+			desc: "tagged field",
+			src:  BothTagged{Left{X: 1, L: 2}, TaggedRight{X: 3}},
+			typ:  reflect.TypeOf(map[string]interface{}{}),
+			res:  map[string]interface{}{"X": 3, "L": 2},
+		},
+		{
+			desc: "time to string",
+			src:  Audit{ID: 7, Created: created},
+			typ:  reflect.TypeOf(map[string]string{}),
+			res:  map[string]string{"id": "7", "created": "2022-01-02T03:04:05Z"},
+		},
+		{
+			desc: "field error",
+			src:  map[string]interface{}{"age": "old"},
+			typ:  reflect.TypeOf(Account{}),
+			err:  `field age: unable to cast "old" of type string to int64`,
+		},
+		{
+			desc: "time error",
+			src:  map[string]interface{}{"created": "yesterday"},
+			typ:  reflect.TypeOf(Audit{}),
+			err:  `field created: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			res, err := newConverter(&hooks{}, ConversionPolicy{}).rconv(reflect.ValueOf(test.src), test.typ)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("got error %v, want %s", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(res.Interface(), test.res) {
+				t.Errorf("got %#v, want %#v", res.Interface(), test.res)
+			}
+		})
+	}
+}
+
+func BenchmarkStructConversion(b *testing.B) {
+	c := newConverter(&hooks{}, ConversionPolicy{})
+	m := map[string]interface{}{"id": 7, "name": "bob", "age": 30, "tags": []interface{}{"a", "b"}, "addr": map[string]interface{}{"city": "Paris"}}
+	account := Account{Audit: &Audit{ID: 7}, Name: "bob", Age: 30, Tags: []string{"a", "b"}, Addr: &Address{City: "Paris"}}
+	accountType := reflect.TypeOf(account)
+	mapType := reflect.TypeOf(m)
+
+	// jsonConv is the previous conversion path between maps and structs.
+	jsonConv := func(src interface{}, dest interface{}) {
+		buf, err := json.Marshal(src)
+		if err == nil {
+			err = json.Unmarshal(buf, dest)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("MapToStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.rconv(reflect.ValueOf(m), accountType); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("MapToStructJSON", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			jsonConv(m, &Account{})
+		}
+	})
+	b.Run("StructToMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.rconv(reflect.ValueOf(account), mapType); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("StructToMapJSON", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			jsonConv(account, &map[string]interface{}{})
+		}
+	})
+	b.Run("StructToStruct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.rconv(reflect.ValueOf(account), reflect.TypeOf(AccountView{})); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("StructToStructCopier", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := copier.Copy(&AccountView{}, account); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestPointerConversion(t *testing.T) {
+	type celsius struct{ Deg float64 }
+	type fahrenheit struct{ Deg float64 }
+	type label func(int) string
+
+	c := newConverter(&hooks{}, ConversionPolicy{})
+	temp := &celsius{Deg: 20}
+
+	res, err := c.rconv(reflect.ValueOf(temp), reflect.TypeOf(&fahrenheit{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Interface().(*fahrenheit).Deg = 68
+	if temp.Deg != 68 {
+		t.Errorf("got %v, want the converted pointer to reference the original value", temp.Deg)
+	}
+
+	if _, err := c.rconv(reflect.ValueOf(temp), reflect.TypeOf(&Address{})); err != errPointerConversion {
+		t.Errorf("got error %v, want %v", err, errPointerConversion)
+	}
+
+	res, err = c.rconv(reflect.ValueOf((*celsius)(nil)), reflect.TypeOf(&fahrenheit{}))
+	if err != nil || !res.IsNil() {
+		t.Errorf("got %v, %v, want a nil pointer", res, err)
+	}
+
+	res, err = c.rconv(reflect.ValueOf(map[string]interface{}{"city": "Paris"}), reflect.TypeOf(&Address{}))
+	if err != nil || res.Interface().(*Address).City != "Paris" {
+		t.Errorf("got %v, %v, want a pointer to a converted value", res, err)
+	}
+
+	res, err = c.rconv(reflect.ValueOf([]interface{}{1, "2", 3.0, 4}), reflect.TypeOf([3]int{}))
+	if err != nil || res.Interface() != [3]int{1, 2, 3} {
+		t.Errorf("got %v, %v, want [1 2 3]", res, err)
+	}
+	if _, err := c.rconv(reflect.ValueOf(1), reflect.TypeOf([3]int{})); err != errArrayConversion {
+		t.Errorf("got error %v, want %v", err, errArrayConversion)
+	}
+
+	res, err = c.rconv(reflect.ValueOf(func(i int) string { return fmt.Sprint(i) }), reflect.TypeOf(label(nil)))
+	if err != nil || res.Interface().(label)(3) != "3" {
+		t.Errorf("got %v, %v, want a converted func", res, err)
+	}
+	if _, err := c.rconv(reflect.ValueOf(func() {}), reflect.TypeOf(label(nil))); err != errFuncConversion {
+		t.Errorf("got error %v, want %v", err, errFuncConversion)
+	}
+}
+
+func TestTimeCoercion(t *testing.T) {
+	epoch := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		desc string
+		src  interface{}
+		typ  reflect.Type
+		res  interface{}
+		err  bool
+	}{
+		{desc: "string to duration", src: "1m30s", typ: durationType, res: 90 * time.Second},
+		{desc: "invalid string to duration", src: "abc", typ: durationType, err: true},
+		{desc: "int to duration", src: 5, typ: durationType, res: time.Duration(5)},
+		{desc: "duration to string", src: 250 * time.Millisecond, typ: reflect.TypeOf(""), res: "250ms"},
+		{desc: "duration to int64", src: time.Second, typ: reflect.TypeOf(int64(0)), res: int64(1e9)},
+		{desc: "string to time", src: "2023-11-14T22:13:20Z", typ: timeType, res: epoch},
+		{desc: "numeric string to time", src: "1700000000", typ: timeType, res: epoch},
+		{desc: "invalid string to time", src: "yesterday", typ: timeType, err: true},
+		{desc: "int to time", src: 1700000000, typ: timeType, res: epoch},
+		{desc: "uint to time", src: uint(1700000000), typ: timeType, res: epoch},
+		{desc: "float to time", src: 1700000000.5, typ: timeType, res: epoch.Add(500 * time.Millisecond)},
+		{desc: "time to string", src: epoch, typ: reflect.TypeOf(""), res: "2023-11-14T22:13:20Z"},
+		{desc: "time to int", src: epoch, typ: reflect.TypeOf(0), res: 1700000000},
+		{desc: "time to float", src: epoch.Add(500 * time.Millisecond), typ: reflect.TypeOf(0.0), res: 1700000000.5},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			res, err := newConverter(&hooks{}, ConversionPolicy{}).rconv(reflect.ValueOf(test.src), test.typ)
+			if test.err {
+				if err == nil {
+					t.Fatalf("got %v, want an error", res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := res.Interface(); !reflect.DeepEqual(got, test.res) {
+				t.Errorf("got %#v, want %#v", got, test.res)
+			}
+		})
+	}
+}
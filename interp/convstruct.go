@@ -0,0 +1,370 @@
+package interp
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Native conversions between structs and maps.
+//
+// Fields are matched by key: the name given by the yaegi struct tag, or else
+// by the json struct tag, or else the field name as declared in source. The
+// match is exact, or else case insensitive, as in encoding/json. A "-" tag
+// excludes the field, and the omitempty option omits zero fields from the
+// maps built from structs. The fields of embedded structs are promoted,
+// unless the embedded field is tagged, with the encoding/json rules on a
+// conflict: the shallowest field wins, or the only tagged one at the
+// shallowest depth, and the key is dropped otherwise. Values are converted by rconv, so nested structs, maps, slices,
+// arrays and pointers are converted recursively. As the converted struct is
+// a copy, a pointer to a value of another type converts to a pointer to a
+// converted copy. Values implementing encoding.TextMarshaler or
+// encoding.TextUnmarshaler, such as time.Time, convert from and to strings.
+//
+// The fields of a struct type and the field matches between two struct
+// types are computed once and cached.
+
+// A convField is a field of a struct type, which may be promoted from
+// embedded structs.
+type convField struct {
+	key       string       // name the field is matched by
+	index     []int        // index sequence, for reflect.Value.FieldByIndex
+	typ       reflect.Type // type of the field
+	omitEmpty bool         // omit the zero field in maps
+}
+
+// A structPlan holds the fields of a struct type, by exact and folded key.
+type structPlan struct {
+	fields []convField
+	byKey  map[string]*convField
+	byFold map[string]*convField
+}
+
+// A fieldMatch is a pair of fields matched by a struct to struct conversion.
+type fieldMatch struct {
+	src, dest *convField
+}
+
+var (
+	structPlans  sync.Map // reflect.Type -> *structPlan
+	fieldMatches sync.Map // convKey -> []fieldMatch
+
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// planOf returns the fields of struct type t.
+func planOf(t reflect.Type) *structPlan {
+	if p, ok := structPlans.Load(t); ok {
+		return p.(*structPlan)
+	}
+	p := &structPlan{byKey: map[string]*convField{}, byFold: map[string]*convField{}}
+	p.fields = collectFields(t)
+	for i := range p.fields {
+		f := &p.fields[i]
+		p.byKey[f.key] = f
+		if k := strings.ToLower(f.key); p.byFold[k] == nil {
+			p.byFold[k] = f
+		}
+	}
+	v, _ := structPlans.LoadOrStore(t, p)
+	return v.(*structPlan)
+}
+
+// collectFields returns the fields of struct type t and the fields promoted
+// from its embedded structs, as encoding/json does: the embedded structs are
+// explored breadth first, each struct type once, and a key is given to the
+// shallowest field, or to the only tagged one at the shallowest depth. The
+// other fields of the key are hidden, or ambiguous and dropped.
+func collectFields(t reflect.Type) []convField {
+	var fields []convField
+	var tagged []bool
+	visited := map[reflect.Type]bool{}
+	for next := []convField{{typ: t}}; len(next) > 0; {
+		current := next
+		next = nil
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				key, omitEmpty, skip := fieldKey(sf)
+				if skip || sf.PkgPath != "" {
+					// Excluded, or unexported field of a binary type.
+					continue
+				}
+				idx := append(append([]int{}, e.index...), i)
+				if et := sf.Type; sf.Anonymous && key == "" {
+					if et.Kind() == reflect.Ptr {
+						et = et.Elem()
+					}
+					if et.Kind() == reflect.Struct && !isTextValue(et) {
+						next = append(next, convField{index: idx, typ: et})
+						continue
+					}
+				}
+				tagged = append(tagged, key != "")
+				if key == "" {
+					key = sourceFieldName(e.typ, sf)
+				}
+				fields = append(fields, convField{key: key, index: idx, typ: sf.Type, omitEmpty: omitEmpty})
+			}
+		}
+	}
+
+	// The fields are in order of depth: keep the dominant field of each key.
+	type dominance struct {
+		field  int // index of the dominant field, or -1 if ambiguous
+		depth  int
+		tagged bool
+	}
+	keys := map[string]*dominance{}
+	for i, f := range fields {
+		d := keys[f.key]
+		switch {
+		case d == nil:
+			keys[f.key] = &dominance{field: i, depth: len(f.index), tagged: tagged[i]}
+		case len(f.index) > d.depth:
+			// Hidden by a shallower field.
+		case tagged[i] && !d.tagged:
+			d.field, d.tagged = i, true
+		case tagged[i] == d.tagged:
+			d.field = -1
+		}
+	}
+	res := fields[:0]
+	for i, f := range fields {
+		if keys[f.key].field == i {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// fieldKey returns the key given to field f by its yaegi or json tag, or ""
+// if untagged, and whether the field is omitted when empty or always skipped.
+func fieldKey(f reflect.StructField) (key string, omitEmpty, skip bool) {
+	tag, ok := f.Tag.Lookup("yaegi")
+	if !ok {
+		tag = f.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	opts := strings.Split(tag, ",")
+	for _, o := range opts[1:] {
+		omitEmpty = omitEmpty || o == "omitempty"
+	}
+	return opts[0], omitEmpty, false
+}
+
+// isTextValue returns true if values of type t convert from and to text,
+// and are therefore not decomposed in fields, as time.Time.
+func isTextValue(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// matchesOf returns the fields of struct type from matched to the fields
+// of struct type to.
+func matchesOf(from, to reflect.Type) []fieldMatch {
+	key := convKey{from, to}
+	if m, ok := fieldMatches.Load(key); ok {
+		return m.([]fieldMatch)
+	}
+	src, dest := planOf(from), planOf(to)
+	var matches []fieldMatch
+	for i := range dest.fields {
+		if sf := src.lookup(dest.fields[i].key); sf != nil {
+			matches = append(matches, fieldMatch{sf, &dest.fields[i]})
+		}
+	}
+	m, _ := fieldMatches.LoadOrStore(key, matches)
+	return m.([]fieldMatch)
+}
+
+// lookup returns the field matched by key, or nil.
+func (p *structPlan) lookup(key string) *convField {
+	if f := p.byKey[key]; f != nil {
+		return f
+	}
+	return p.byFold[strings.ToLower(key)]
+}
+
+// fieldRead returns the field of struct v at index, or an invalid value if
+// it is promoted through a nil pointer.
+func fieldRead(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldWrite returns the field of struct v at index, allocating the nil
+// pointers to embedded structs on the way.
+func fieldWrite(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// convertField converts the value v to the type of field f of dest, and
+// sets it. Nil values leave the field unset.
+func (c *converter) convertField(dest reflect.Value, f *convField, v reflect.Value) error {
+	if isNilValue(concreteValue(v)) {
+		return nil
+	}
+	cv, err := c.convertValue(v, f.typ)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.key, err)
+	}
+	fieldWrite(dest, f.index).Set(cv)
+	return nil
+}
+
+// convertValue converts v to type t, for a field or a map element.
+func (c *converter) convertValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if v = concreteValue(v); !v.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if c.coercion(v.Type(), t) == nil {
+		if cv, ok, err := convertText(v, t); ok {
+			return cv, err
+		}
+	}
+	if v.Kind() == reflect.Ptr && t.Kind() == reflect.Ptr && !v.Type().ConvertibleTo(t) {
+		// The converted struct is a copy, and so are the values it points to.
+		if v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		e, err := c.convertValue(v.Elem(), t.Elem())
+		if err != nil {
+			return v, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, nil
+	}
+	cv, err := c.rconv(v, t)
+	if err == nil && !cv.Type().AssignableTo(t) {
+		err = fmt.Errorf("cannot convert %v to %v", v.Type(), t)
+	}
+	return cv, err
+}
+
+// convertText converts between strings and the values implementing
+// encoding.TextMarshaler or encoding.TextUnmarshaler. It reports false
+// if it does not apply.
+func convertText(v reflect.Value, t reflect.Type) (reflect.Value, bool, error) {
+	switch {
+	case !v.IsValid():
+		return v, false, nil
+	case v.Kind() == reflect.String && reflect.PtrTo(t).Implements(textUnmarshalerType):
+		p := reflect.New(t)
+		err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v.String()))
+		return p.Elem(), true, err
+	case t.Kind() == reflect.String && v.Type().Implements(textMarshalerType):
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return reflect.ValueOf(string(b)).Convert(t), true, err
+	}
+	return v, false, nil
+}
+
+// structToStruct converts struct src to struct type t, field by field.
+func (c *converter) structToStruct(src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	dest := reflect.New(t).Elem()
+	for _, m := range matchesOf(src.Type(), t) {
+		v := fieldRead(src, m.src.index)
+		if !v.IsValid() {
+			continue
+		}
+		if err := c.convertField(dest, m.dest, v); err != nil {
+			return src, err
+		}
+	}
+	return dest, nil
+}
+
+// mapToStruct converts map src to struct type t, setting the fields
+// matched by the map keys.
+func (c *converter) mapToStruct(src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	dest := reflect.New(t).Elem()
+	p := planOf(t)
+	iter := src.MapRange()
+	for iter.Next() {
+		k := concreteValue(iter.Key())
+		var key string
+		if k.Kind() == reflect.String {
+			key = k.String()
+		} else {
+			key = fmt.Sprint(k.Interface())
+		}
+		f := p.lookup(key)
+		if f == nil {
+			continue
+		}
+		if err := c.convertField(dest, f, iter.Value()); err != nil {
+			return src, err
+		}
+	}
+	return dest, nil
+}
+
+// structToMap converts struct src to map type t, with an entry per field.
+func (c *converter) structToMap(src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	p := planOf(src.Type())
+	dest := reflect.MakeMapWithSize(t, len(p.fields))
+	ktyp, etyp := t.Key(), t.Elem()
+	for i := range p.fields {
+		f := &p.fields[i]
+		v := fieldRead(src, f.index)
+		if !v.IsValid() || f.omitEmpty && v.IsZero() {
+			continue
+		}
+		k, err := c.convertValue(reflect.ValueOf(f.key), ktyp)
+		if err != nil {
+			return src, fmt.Errorf("field %s: %w", f.key, err)
+		}
+		if v, err = c.convertValue(v, etyp); err != nil {
+			return src, fmt.Errorf("field %s: %w", f.key, err)
+		}
+		dest.SetMapIndex(k, v)
+	}
+	return dest, nil
+}
+
+// sequenceToArray converts the slice or array src to array type t, element
+// by element. Extra elements are dropped, missing ones are zero.
+func (c *converter) sequenceToArray(src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	dest := reflect.New(t).Elem()
+	for i := 0; i < src.Len() && i < t.Len(); i++ {
+		v, err := c.convertValue(src.Index(i), t.Elem())
+		if err != nil {
+			return src, err
+		}
+		dest.Index(i).Set(v)
+	}
+	return dest, nil
+}
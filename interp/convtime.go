@@ -0,0 +1,133 @@
+package interp
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Builtin coercions of time values.
+//
+// In the implicit conversions, durations and times are not handled as the
+// int64 and struct values they are made of, but as follows:
+//
+//	string      time.Duration  parsed by time.ParseDuration, e.g. "1m30s"
+//	number      time.Duration  nanoseconds, as in a Go conversion
+//	time.Duration  string      formatted by time.Duration.String
+//	time.Duration  number      nanoseconds, as in a Go conversion
+//	string      time.Time      parsed as RFC 3339, or as a number of seconds
+//	number      time.Time      seconds since the Unix epoch, in UTC
+//	time.Time   string         formatted as RFC 3339 with nanoseconds
+//	time.Time   number         seconds since the Unix epoch, with a fraction for floats
+
+// coerceFn converts src to type t according to the conversion rules of c.
+type coerceFn func(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// coercionOf returns the builtin coercion from type from to type to,
+// or nil if there is none.
+func coercionOf(from, to reflect.Type) coerceFn {
+	if from == nil || to == nil || from == to {
+		return nil
+	}
+	switch {
+	case to == durationType:
+		switch {
+		case from.Kind() == reflect.String:
+			return stringToDuration
+		case isRealNumber(from):
+			return convertNumber
+		}
+	case from == durationType:
+		switch {
+		case to.Kind() == reflect.String:
+			return durationToString
+		case isRealNumber(to):
+			return convertNumber
+		}
+	case to == timeType:
+		switch {
+		case from.Kind() == reflect.String:
+			return stringToTime
+		case isRealNumber(from):
+			return numberToTime
+		}
+	case from == timeType:
+		switch {
+		case to.Kind() == reflect.String:
+			return timeToString
+		case isRealNumber(to):
+			return timeToNumber
+		}
+	}
+	return nil
+}
+
+// isRealNumber returns true if t is an integer or a float type.
+func isRealNumber(t reflect.Type) bool {
+	return isNumber(t) && !isComplex(t)
+}
+
+func convertNumber(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	return src.Convert(t), nil
+}
+
+func stringToDuration(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(src.String()))
+	if err != nil {
+		return src, err
+	}
+	return reflect.ValueOf(d).Convert(t), nil
+}
+
+func durationToString(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	return reflect.ValueOf(time.Duration(src.Int()).String()).Convert(t), nil
+}
+
+func stringToTime(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	s := strings.TrimSpace(src.String())
+	tm, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(c.rules().number(s), 64)
+		if ferr != nil {
+			return src, err
+		}
+		tm = unixTime(f)
+	}
+	return reflect.ValueOf(tm), nil
+}
+
+func numberToTime(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	switch {
+	case isUint(src.Type()):
+		return reflect.ValueOf(time.Unix(int64(src.Uint()), 0).UTC()), nil
+	case isInt(src.Type()):
+		return reflect.ValueOf(time.Unix(src.Int(), 0).UTC()), nil
+	}
+	return reflect.ValueOf(unixTime(src.Float())), nil
+}
+
+// unixTime returns the UTC time at sec seconds since the Unix epoch.
+func unixTime(sec float64) time.Time {
+	s, frac := math.Modf(sec)
+	return time.Unix(int64(s), int64(math.Round(frac*1e9))).UTC()
+}
+
+func timeToString(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	tm := src.Interface().(time.Time)
+	return reflect.ValueOf(tm.Format(time.RFC3339Nano)).Convert(t), nil
+}
+
+func timeToNumber(c *converter, src reflect.Value, t reflect.Type) (reflect.Value, error) {
+	tm := src.Interface().(time.Time)
+	if isFloat(t) {
+		return reflect.ValueOf(float64(tm.UnixNano()) / 1e9).Convert(t), nil
+	}
+	return reflect.ValueOf(tm.Unix()).Convert(t), nil
+}
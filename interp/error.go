@@ -3,6 +3,7 @@ package interp
 import (
 	"fmt"
 	"go/token"
+	"reflect"
 	"strings"
 )
 
@@ -77,3 +78,103 @@ func (n *node) runErrorf(format string, a ...interface{}) *runError {
 	end := n.interp.fset.Position(n.end)
 	return &runError{pos, end, fmt.Sprintf(format, a...)}
 }
+
+// A ConversionError represents the failure of an implicit conversion at runtime.
+type ConversionError struct {
+	Position token.Position // position of the node performing the conversion
+	Source   reflect.Type   // type of the value to convert, nil if invalid
+	Target   reflect.Type   // expected type
+	Value    interface{}    // value to convert, nil if not available
+	Err      error          // underlying conversion error
+	end      token.Position
+}
+
+func (c *ConversionError) Pos() token.Position {
+	return c.Position
+}
+
+func (c *ConversionError) End() token.Position {
+	return c.end
+}
+
+func (c *ConversionError) Reason() string {
+	reason := fmt.Sprintf("failed to convert %v to %v", c.Source, c.Target)
+	if c.Err != nil {
+		reason += ": " + c.Err.Error()
+	}
+	return reason
+}
+
+func (c *ConversionError) Error() string {
+	posString := c.Position.String()
+	if c.Position.Filename == DefaultSourceName {
+		posString = strings.TrimPrefix(posString, DefaultSourceName+":")
+	}
+	return fmt.Sprintf("%s %s", posString, c.Reason())
+}
+
+// Unwrap returns the underlying conversion error.
+func (c *ConversionError) Unwrap() error {
+	return c.Err
+}
+
+// maxStackCalls is the maximum number of calls recorded in the stack of a
+// CallDepthError.
+const maxStackCalls = 100
+
+// A CallDepthError is the value of the panic raised by an interpreted call
+// exceeding the maximum call depth.
+type CallDepthError struct {
+	MaxDepth int              // maximum depth of interpreted calls
+	Stack    []token.Position // positions of the innermost interpreted calls, innermost first
+}
+
+func (e *CallDepthError) Error() string {
+	posString := e.Stack[0].String()
+	if e.Stack[0].Filename == DefaultSourceName {
+		posString = strings.TrimPrefix(posString, DefaultSourceName+":")
+	}
+	return fmt.Sprintf("%s stack overflow: maximum call depth of %d exceeded", posString, e.MaxDepth)
+}
+
+func (n *node) callDepthError(max int) *CallDepthError {
+	return &CallDepthError{MaxDepth: max, Stack: []token.Position{n.interp.fset.Position(n.pos)}}
+}
+
+// addCall records the call n in the stack of e, while the panic unwinds it.
+func (e *CallDepthError) addCall(n *node) {
+	if len(e.Stack) < maxStackCalls {
+		e.Stack = append(e.Stack, n.interp.fset.Position(n.pos))
+	}
+}
+
+func (n *node) conversionError(v reflect.Value, t reflect.Type, err error) *ConversionError {
+	e := &ConversionError{
+		Position: n.interp.fset.Position(n.pos),
+		Target:   t,
+		Err:      err,
+		end:      n.interp.fset.Position(n.end),
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.IsValid() {
+		e.Source = v.Type()
+		if v.CanInterface() {
+			e.Value = v.Interface()
+		}
+	}
+	return e
+}
+
+// unrecoverable returns true if the panic value r must stop the execution
+// of the program instead of being recovered by the interpreted code.
+func (interp *Interpreter) unrecoverable(r interface{}) bool {
+	switch r.(type) {
+	case *ConversionError:
+		return interp.convFailure == ConversionFailError
+	case *ErrBudgetExceeded, *ErrAllocExceeded:
+		return true
+	}
+	return false
+}
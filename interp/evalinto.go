@@ -0,0 +1,110 @@
+package interp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// EvalInto evaluates Go code represented as a string, and stores its last
+// result into the Go variable pointed to by dest. If the code ends with a call
+// returning several values, dest is a []interface{} holding a pointer to the
+// destination of each value. Values not assignable to their destination are
+// converted implicitly, except in strict mode.
+func (interp *Interpreter) EvalInto(src string, dest interface{}) error {
+	interp.arm(interp.budget)
+	prog, err := interp.compile(src, "", true)
+	if err != nil || interp.noRun {
+		return err
+	}
+	res, err := interp.execute(prog, interp.budget)
+	if err != nil {
+		return err
+	}
+	return interp.store(dest, interp.results(prog, res))
+}
+
+// EvalIntoWithContext is like EvalInto, but the execution of the code is
+// interrupted when ctx is done, in which case ctx.Err() is returned.
+func (interp *Interpreter) EvalIntoWithContext(ctx context.Context, src string, dest interface{}) error {
+	interp.arm(interp.budgetOf(ctx))
+	prog, err := interp.compile(src, "", true)
+	if err != nil || interp.noRun {
+		return err
+	}
+	res, err := interp.executeWithContext(ctx, prog)
+	if err != nil {
+		return err
+	}
+	return interp.store(dest, interp.results(prog, res))
+}
+
+// results returns the values computed by the last statement of program p, of
+// which res is the first one. A final call returns all its results, and a
+// declaration none.
+func (interp *Interpreter) results(p *Program, res reflect.Value) []reflect.Value {
+	n := p.root
+	for len(n.child) > 0 && (n.kind == blockStmt || n.kind == exprStmt) {
+		n = n.lastChild()
+	}
+	if n.kind == fileStmt {
+		// Declarations have no value.
+		return nil
+	}
+	if n.kind != callExpr || n.child[0].typ == nil {
+		return []reflect.Value{res}
+	}
+	k := n.child[0].typ.numOut()
+	switch {
+	case k == 0:
+		return nil
+	case k == 1 || n.level != 0 || n.findex < 0:
+		return []reflect.Value{res}
+	}
+
+	vals := []reflect.Value{res}
+	interp.frame.mutex.RLock()
+	defer interp.frame.mutex.RUnlock()
+	for i := 1; i < k && n.findex+i < len(interp.frame.data); i++ {
+		v := interp.frame.data[n.findex+i]
+		if v.IsValid() && v.CanInterface() {
+			if fn, ok := v.Interface().(*node); ok {
+				v = genFunctionWrapper(fn)(interp.frame)
+			}
+		}
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// store stores vals into dest, a pointer or a []interface{} of pointers, as
+// given to EvalInto.
+func (interp *Interpreter) store(dest interface{}, vals []reflect.Value) error {
+	ptrs, ok := dest.([]interface{})
+	if !ok {
+		ptrs = []interface{}{dest}
+	}
+	if len(ptrs) != len(vals) {
+		return fmt.Errorf("assignment mismatch: %d destinations but %d values", len(ptrs), len(vals))
+	}
+
+	for i, p := range ptrs {
+		ptr := reflect.ValueOf(p)
+		if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+			return fmt.Errorf("destination %d: want a non-nil pointer, got %T", i, p)
+		}
+		v := vals[i]
+		if !v.IsValid() {
+			return fmt.Errorf("destination %d: no value to store", i)
+		}
+		if !v.CanInterface() {
+			return fmt.Errorf("destination %d: cannot access value of type %v", i, v.Type())
+		}
+		cv, err := interp.bindValue(v.Interface(), ptr.Elem().Type())
+		if err != nil {
+			return fmt.Errorf("destination %d: %w", i, err)
+		}
+		ptr.Elem().Set(cv)
+	}
+	return nil
+}
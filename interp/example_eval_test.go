@@ -36,3 +36,26 @@ func Example_eval() {
 	// Output:
 	// 4
 }
+
+// Evaluation of an expression with variables bound per call.
+func ExampleInterpreter_EvalExpr() {
+	i := interp.New(interp.Options{})
+
+	_, err := i.Eval(`func discount(total float64) float64 { return total * 0.1 }`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The expression is compiled by the first call, and reused by the next ones.
+	for _, total := range []float64{50, 200} {
+		v, err := i.EvalExpr(`total > 100 && discount(total) < limit`, map[string]interface{}{"total": total, "limit": 25.0})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(total, v)
+	}
+
+	// Output:
+	// 50 false
+	// 200 true
+}
@@ -0,0 +1,217 @@
+package interp
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// An Expr is a Go expression compiled against a set of free variables, which
+// can be evaluated many times with different values bound to its variables.
+// The expression may refer to the symbols of the main package, but it does
+// not declare anything in it.
+type Expr struct {
+	interp *Interpreter
+	src    string
+	names  []string       // free variables, sorted by name
+	types  []reflect.Type // types of the free variables, in names order
+	fn     reflect.Value  // compiled func(vars...) interface{}
+}
+
+// exprCache holds the expressions compiled by EvalExpr, by source.
+type exprCache struct {
+	sync.Mutex
+	m map[string][]*Expr
+}
+
+// CompileExpr compiles the Go expression src, in which the free variables
+// named by the keys of vars have the types given by the values. A nil type
+// stands for interface{}.
+func (interp *Interpreter) CompileExpr(src string, vars map[string]reflect.Type) (*Expr, error) {
+	x, err := parser.ParseExprFrom(interp.fset, DefaultSourceName, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Expr{interp: interp, src: src}
+	for name := range vars {
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("invalid variable name: %q", name)
+		}
+		e.names = append(e.names, name)
+	}
+	sort.Strings(e.names)
+
+	// The expression is compiled as the result of a function literal taking the
+	// free variables as parameters. Their types are given by symbols of a scope
+	// private to the expression, named so they can not be referred to in src.
+	sc := interp.initScopePkg(mainID, mainID).pushBloc()
+	params := &ast.FieldList{}
+	for i, name := range e.names {
+		t := vars[name]
+		if t == nil {
+			t = reflect.TypeOf((*interface{})(nil)).Elem()
+		}
+		e.types = append(e.types, t)
+		tname := "#" + strconv.Itoa(i)
+		sc.sym[tname] = &symbol{kind: typeSym, typ: interp.typeOfValue(t)}
+		params.List = append(params.List, &ast.Field{Names: []*ast.Ident{ast.NewIdent(name)}, Type: ast.NewIdent(tname)})
+	}
+	results := &ast.FieldList{List: []*ast.Field{{Type: &ast.InterfaceType{Methods: &ast.FieldList{}}}}}
+	lit := &ast.FuncLit{
+		Type: &ast.FuncType{Params: params, Results: results},
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{x}}}},
+	}
+
+	_, root, err := interp.ast(&ast.BlockStmt{Lbrace: x.Pos(), List: []ast.Stmt{&ast.ExprStmt{X: lit}}})
+	if err != nil {
+		return nil, err
+	}
+	_, err = interp.cfg(root, sc, mainID, mainID)
+	sc.pop()
+	if err != nil {
+		return nil, err
+	}
+
+	// The function literal is defined at global level, and takes the frame of
+	// the main package as its closure context.
+	var fn *node
+	root.Walk(func(n *node) bool {
+		if n.kind == funcLit {
+			fn = n
+		}
+		return fn == nil
+	}, nil)
+	interp.frame.mutex.Lock()
+	interp.resizeFrame()
+	interp.frame.mutex.Unlock()
+	e.fn = genFunctionWrapper(fn)(interp.frame)
+	return e, nil
+}
+
+// typeOfValue returns the interpreter type of values of runtime type t,
+// using the predeclared types where possible.
+func (interp *Interpreter) typeOfValue(t reflect.Type) *itype {
+	if t.PkgPath() == "" {
+		if s := interp.universe.sym[t.String()]; s != nil && s.kind == typeSym && s.typ.TypeOf() == t {
+			return s.typ
+		}
+	}
+	return valueTOf(t)
+}
+
+// Vars returns the names of the free variables of e, sorted.
+func (e *Expr) Vars() []string {
+	return append([]string{}, e.names...)
+}
+
+// Eval evaluates e with the given values bound to its free variables, and
+// returns the result. All the free variables must be bound. A value not
+// assignable to the type of its variable is converted implicitly, except in
+// strict mode.
+func (e *Expr) Eval(vars map[string]interface{}) (res reflect.Value, err error) {
+	args := make([]reflect.Value, len(e.names))
+	for i, name := range e.names {
+		v, ok := vars[name]
+		if !ok {
+			return res, fmt.Errorf("%s: missing value for variable %s", e.src, name)
+		}
+		if args[i], err = e.interp.bindValue(v, e.types[i]); err != nil {
+			return res, fmt.Errorf("%s: variable %s: %w", e.src, name, err)
+		}
+	}
+
+	defer e.interp.recoverPanic(&err)
+
+	res = e.fn.Call(args)[0]
+	if !res.IsNil() {
+		res = res.Elem()
+	} else {
+		res = reflect.Value{}
+	}
+	return res, nil
+}
+
+// bindValue returns v as a value of type t, for a free variable.
+func (interp *Interpreter) bindValue(v interface{}, t reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return reflect.Zero(t), nil
+	case rv.Type().AssignableTo(t):
+		return rv, nil
+	case interp.strict:
+		return rv, fmt.Errorf("cannot use %v as %v", rv.Type(), t)
+	}
+	cv, err := interp.conv.rconv(rv, t)
+	if err == nil && !cv.Type().AssignableTo(t) {
+		err = fmt.Errorf("cannot convert %v to %v", rv.Type(), t)
+	}
+	return cv, err
+}
+
+// EvalExpr evaluates the Go expression src, with the given values bound to
+// its free variables, and returns the result. The type of each variable is
+// the type of its value, or interface{} for a nil value. The expression is
+// compiled once per set of variable types, and reused by the next calls.
+// No symbol is declared in the main package, so the results of Globals are
+// unchanged.
+func (interp *Interpreter) EvalExpr(src string, vars map[string]interface{}) (reflect.Value, error) {
+	types := make(map[string]reflect.Type, len(vars))
+	for name, v := range vars {
+		types[name] = reflect.TypeOf(v)
+	}
+
+	interp.exprs.Lock()
+	e := interp.exprs.lookup(src, types)
+	interp.exprs.Unlock()
+	if e == nil {
+		var err error
+		if e, err = interp.CompileExpr(src, types); err != nil {
+			return reflect.Value{}, err
+		}
+		interp.exprs.Lock()
+		if interp.exprs.m == nil {
+			interp.exprs.m = map[string][]*Expr{}
+		}
+		interp.exprs.m[src] = append(interp.exprs.m[src], e)
+		interp.exprs.Unlock()
+	}
+	return e.Eval(vars)
+}
+
+// lookup returns the expression compiled from src with the variable types,
+// or nil.
+func (c *exprCache) lookup(src string, types map[string]reflect.Type) *Expr {
+	for _, e := range c.m[src] {
+		if e.hasTypes(types) {
+			return e
+		}
+	}
+	return nil
+}
+
+// hasTypes returns true if the free variables of e have the given types.
+func (e *Expr) hasTypes(types map[string]reflect.Type) bool {
+	if len(types) != len(e.names) {
+		return false
+	}
+	for i, name := range e.names {
+		t, ok := types[name]
+		if !ok {
+			return false
+		}
+		if t == nil {
+			t = reflect.TypeOf((*interface{})(nil)).Elem()
+		}
+		if t != e.types[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package interp
+
+import (
+	"go/token"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// A Goroutine describes a live goroutine started by a go statement of the
+// interpreted code.
+type Goroutine struct {
+	ID  uint64         // identifier, in order of start
+	Pos token.Position // position of the go statement
+}
+
+// goroutines tracks the goroutines started by the interpreted code.
+type goroutines struct {
+	sync.Mutex
+	wg   sync.WaitGroup
+	last uint64           // identifier of the last started goroutine
+	live map[uint64]*node // go statement of the live goroutines, by identifier
+	err  error            // error of the first goroutine which panicked, not yet reported
+}
+
+// spawn runs fn in a new goroutine for the go statement of call n, and
+// panics if the maximum number of live goroutines is reached. A panic of the
+// goroutine, such as an exceeded budget or call depth, ends it without
+// crashing the process, and is reported by the next execution or by Close.
+func (interp *Interpreter) spawn(n *node, fn func()) {
+	g := &interp.goroutines
+	g.Lock()
+	if max := interp.goLimit; max > 0 && len(g.live) >= max {
+		g.Unlock()
+		panic(n.runErrorf("too many goroutines: limit of %d reached", max))
+	}
+	if g.live == nil {
+		g.live = map[uint64]*node{}
+	}
+	g.last++
+	id := g.last
+	g.live[id] = n.anc
+	g.wg.Add(1)
+	g.Unlock()
+
+	go func() {
+		var err error
+		defer func() {
+			g.Lock()
+			delete(g.live, id)
+			if g.err == nil {
+				g.err = err
+			}
+			g.Unlock()
+			g.wg.Done()
+		}()
+		defer interp.recoverPanic(&err)
+		fn()
+	}()
+}
+
+// goroutineErr returns the error of the first goroutine which panicked since
+// the last call, or nil.
+func (interp *Interpreter) goroutineErr() error {
+	g := &interp.goroutines
+	g.Lock()
+	defer g.Unlock()
+	err := g.err
+	g.err = nil
+	return err
+}
+
+// Goroutines returns the live goroutines started by the interpreted code,
+// in order of start.
+func (interp *Interpreter) Goroutines() []Goroutine {
+	g := &interp.goroutines
+	g.Lock()
+	defer g.Unlock()
+
+	res := make([]Goroutine, 0, len(g.live))
+	for id, n := range g.live {
+		res = append(res, Goroutine{ID: id, Pos: interp.fset.Position(n.pos)})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+// Close cancels the execution of the interpreted code, and waits for the end
+// of the goroutines started by it. The interpreted goroutines stop at their
+// next statement or channel operation, but the calls to binary functions,
+// such as time.Sleep, are waited for. The interpreter must not be used after
+// Close. The error of a goroutine which panicked, not returned by an
+// execution yet, is returned.
+func (interp *Interpreter) Close() error {
+	interp.mutex.Lock()
+	atomic.AddUint64(&interp.id, 1)
+	select {
+	case <-interp.done:
+		// Already closed by a cancellation.
+	default:
+		close(interp.done)
+	}
+	interp.mutex.Unlock()
+
+	interp.goroutines.wg.Wait()
+	return interp.goroutineErr()
+}
+
+// renewDone replaces the channel closed by a cancellation, for a new
+// execution. It must be called with interp.mutex held.
+func (interp *Interpreter) renewDone() {
+	select {
+	case <-interp.done:
+		interp.done = make(chan struct{})
+	default:
+	}
+	interp.cancelChan = !interp.opt.fastChan
+}
+
+// initDone initializes the channel closed to cancel the channel operations of
+// the interpreted code, which are cancellable unless YAEGI_FAST_CHAN is set.
+func (interp *Interpreter) initDone() {
+	interp.done = make(chan struct{})
+	interp.cancelChan = !interp.opt.fastChan
+	interp.frame.done = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(interp.done)}
+}
@@ -220,6 +220,9 @@ func (interp *Interpreter) gta(root *node, rpath, importPath, pkgName string) ([
 				case "_": // no import of symbols
 				case ".": // import symbols in current scope
 					for n, v := range pkg {
+						if !interp.allowsSymbol(ipath, n) {
+							continue
+						}
 						typ := v.Type()
 						kind := binSym
 						if isBinType(v) {
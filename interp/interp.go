@@ -122,6 +122,9 @@ type frame struct {
 	deferred  [][]reflect.Value  // defer stack
 	recovered interface{}        // to handle panic recover
 	done      reflect.SelectCase // for cancellation of channel operations
+	meter     *meter             // cost meter of the execution, if metered
+	allocs    *allocMeter        // allocation meter of the execution, if limited
+	depth     int                // depth of interpreted calls in the goroutine
 }
 
 func newFrame(anc *frame, length int, id uint64) *frame {
@@ -135,6 +138,8 @@ func newFrame(anc *frame, length int, id uint64) *frame {
 	} else {
 		f.done = anc.done
 		f.root = anc.root
+		f.meter = anc.meter
+		f.allocs = anc.allocs
 	}
 	return f
 }
@@ -152,6 +157,9 @@ func (f *frame) clone(fork bool) *frame {
 		id:        f.runid(),
 		done:      f.done,
 		debug:     f.debug,
+		meter:     f.meter,
+		allocs:    f.allocs,
+		depth:     f.depth,
 	}
 	if fork {
 		nf.data = make([]reflect.Value, len(f.data))
@@ -188,6 +196,18 @@ type opt struct {
 	fastChan     bool              // disable cancellable chan operations
 	specialStdio bool              // allows os.Stdin, os.Stdout, os.Stderr to not be file descriptors
 	unrestricted bool              // allow use of non sandboxed symbols
+	strict       bool              // strict Go semantics, no implicit conversions
+	convFailure  ConversionFailure // policy on implicit conversion failures
+	nilSafe      bool              // dynamic selectors and indexes yield nil instead of panicking
+	coerceAssert bool              // type assertions to concrete types convert dynamic values
+	metered      bool              // executed nodes are charged to the budget
+	budget       int64             // default budget of an execution, 0 for no limit
+	budgetCosts  map[string]int64  // cost of the nodes by kind, 1 if not set
+	maxCallDepth int               // maximum depth of interpreted calls per goroutine, 0 for no limit
+	goLimit      int               // maximum number of live interpreted goroutines, 0 for no limit
+	maxAlloc     int64             // maximum number of bytes allocated by an execution, 0 for no limit
+	allocExec    func(*node)       // allocExec if maxAlloc is set, called indirectly to avoid an initialization cycle
+	symbolPolicy *SymbolPolicy     // policy on the symbols of the binary packages, or nil
 }
 
 // Interpreter contains global resources and state.
@@ -220,7 +240,15 @@ type Interpreter struct {
 	done     chan struct{}     // for cancellation of channel operations
 	roots    []*node
 
-	hooks *hooks // symbol hooks
+	hooks    *hooks          // symbol hooks
+	conv     *converter      // implicit conversions, using convert hooks
+	implicit *implicitReport // implicit conversions found by Analyze, or nil
+	exprs    exprCache       // expressions compiled by EvalExpr
+
+	goroutines goroutines // goroutines started by the interpreted code
+	last       *frame     // frame holding the meters of the last started execution
+
+	policies map[string]*SymbolPolicy // symbol policies given to UseWithPolicy, indexed by import path
 
 	debugger *Debugger
 }
@@ -323,6 +351,142 @@ type Options struct {
 
 	// Unrestricted allows to run non sandboxed stdlib symbols such as os/exec and environment
 	Unrestricted bool
+
+	// Strict selects strict Go semantics instead of the default loose dialect:
+	// no implicit conversions in assignments, conditions, operators and calls,
+	// and no dynamic selectors, indexes or ranges on interface values.
+	//
+	// In the loose dialect, a condition or a logical operand of any type is
+	// tested by its truthiness: nil values, zero numbers, zero structs and
+	// empty arrays, slices, maps and channels are false. A string is true only
+	// if it converts to true by the conversion policy, as "true" or "1": other
+	// strings, including "hello", are false. See also Truthy.
+	Strict bool
+
+	// ConversionFailure selects how a failed implicit conversion is handled
+	// at runtime. It defaults to ConversionFailPanic.
+	ConversionFailure ConversionFailure
+
+	// ConversionPolicy customizes the implicit conversions of the loose dialect.
+	// The zero value keeps the default rules.
+	ConversionPolicy ConversionPolicy
+
+	// NilSafe makes the dynamic selectors and indexes of the loose dialect
+	// nil-safe: navigating through a nil value, a missing field, a map key of
+	// another type or an out of range index yields nil, so that a deep path
+	// such as event.user.address.city can be read on heterogeneous data.
+	// Otherwise, an out of range index or a key of another type panics with a
+	// runtime error, and the other accesses leave the result unset.
+	NilSafe bool
+
+	// CoerceAssertions makes the type assertions of the loose dialect to bool,
+	// number, string, map, slice and struct types convert the dynamic value
+	// with the implicit conversion rules, instead of requiring its type to be
+	// identical: x.(int) yields 42 if x holds "42" or 42.0. The comma-ok form
+	// reports whether the value converts without loss, e.g. false for "4.2".
+	CoerceAssertions bool
+
+	// Budget limits the cost of each execution of Eval, EvalPath, EvalInto,
+	// Execute, their context variants and of each run of a Runner, counted in
+	// executed CFG nodes. An execution exceeding its budget is aborted with an
+	// *ErrBudgetExceeded error. The concurrent executions are metered
+	// separately, and the goroutines started by an execution, and the
+	// functions it returns, are charged to its budget. The interpreted
+	// functions called from Go otherwise, and the expressions, are charged to
+	// a budget reset by each execution. Zero disables the metering. A negative value enables the
+	// metering without limit, e.g. for executions given a budget with
+	// WithBudget.
+	Budget int64
+
+	// BudgetCosts gives the cost of executing a node, by node kind as shown in
+	// the CFG graph, e.g. "callExpr" or "forStmt2". Other nodes cost 1, and a
+	// cost of 0 makes a node free. A non nil table enables the metering.
+	BudgetCosts map[string]int64
+
+	// MaxCallDepth limits the depth of the interpreted function calls of each
+	// goroutine, to stop a runaway recursion before it overflows the stack of
+	// the host process. A call exceeding the limit panics with a
+	// *CallDepthError, which can be recovered by the interpreted code, and is
+	// otherwise returned as a Panic. Zero means no limit.
+	MaxCallDepth int
+
+	// MaxGoroutines limits the number of live goroutines started by the go
+	// statements of the interpreted code. A go statement exceeding the limit
+	// panics. Zero means no limit. A panic of an interpreted goroutine, e.g.
+	// for an exceeded budget, ends the goroutine without crashing the host
+	// process, and is returned by the next execution, or by Close. See also
+	// Goroutines.
+	MaxGoroutines int
+
+	// MaxAlloc limits the approximate number of bytes allocated by each
+	// execution, as for Budget, by make, new, append, composite literals and
+	// string concatenations. The sizes given to make and the size of new,
+	// array and struct values are checked before the allocation. An execution
+	// exceeding the limit is aborted with an *ErrAllocExceeded error, and a
+	// goroutine it started is ended, see MaxGoroutines. Zero means no limit.
+	// The allocations of the binary functions are not counted.
+	MaxAlloc int64
+
+	// SymbolPolicy allows or denies the use of individual symbols and methods
+	// of the binary packages, by pattern. It is checked at compilation, except
+	// for the methods called through an interface, checked at run time on the
+	// type of the dynamic value. A method promoted from a binary type embedded
+	// in an interpreted type, and called through an interface, is not checked.
+	// See also UseWithPolicy.
+	SymbolPolicy *SymbolPolicy
+}
+
+// ConversionPolicy holds the rules applied by the implicit conversions
+// between strings, booleans and numbers in the loose dialect.
+type ConversionPolicy struct {
+	// TrueStrings and FalseStrings are the strings converted to true and false,
+	// compared case insensitively, e.g. "yes", "on" and "no", "off". If set,
+	// other strings fail to convert to bool, and booleans convert to
+	// the first string of the list. By default, the strings accepted by
+	// strconv.ParseBool are used.
+	TrueStrings, FalseStrings []string
+
+	// FloatFormat and FloatPrecision are the format and precision used to
+	// convert floats to strings, as in strconv.FormatFloat. FloatPrecision
+	// is only used if FloatFormat is set. By default, floats are converted
+	// to the smallest decimal representation without exponent.
+	FloatFormat    byte
+	FloatPrecision int
+
+	// DecimalSeparator is the decimal separator used to convert floats to
+	// strings, and strings to numbers, e.g. ',' for locales which require it.
+	// It defaults to '.'.
+	DecimalSeparator rune
+
+	// EmptyStringZero converts the empty string to the zero value of numbers
+	// and booleans, instead of failing.
+	EmptyStringZero bool
+
+	// StrictNil makes the conversion of nil to a non nullable type fail,
+	// instead of yielding the zero value, and restricts the comparisons
+	// to nil to nullable values, instead of considering zero values as nil.
+	StrictNil bool
+}
+
+// ConversionFailure is the policy applied when an implicit conversion fails at runtime.
+type ConversionFailure int
+
+// Conversion failure policies.
+const (
+	// ConversionFailPanic raises a panic with a *ConversionError value,
+	// which can be recovered by the interpreted code.
+	ConversionFailPanic ConversionFailure = iota
+	// ConversionFailError stops the execution, which returns the *ConversionError.
+	ConversionFailError
+	// ConversionFailZero silently uses the zero value of the target type.
+	ConversionFailZero
+)
+
+// Truthy is implemented by values which define their own truthiness when
+// used as conditions or operands of logical operators in the loose dialect.
+// Both binary types and interpreted types can implement it.
+type Truthy interface {
+	Truthy() bool
 }
 
 // New returns a new interpreter.
@@ -339,6 +503,7 @@ func New(options Options) *Interpreter {
 		rdir:     map[string]bool{},
 		hooks:    &hooks{},
 	}
+	i.conv = newConverter(i.hooks, options.ConversionPolicy)
 
 	if i.opt.stdin = options.Stdin; i.opt.stdin == nil {
 		i.opt.stdin = os.Stdin
@@ -370,6 +535,29 @@ func New(options Options) *Interpreter {
 		}
 	}
 
+	i.opt.strict = options.Strict
+	i.opt.convFailure = options.ConversionFailure
+	i.opt.nilSafe = options.NilSafe
+	i.opt.coerceAssert = options.CoerceAssertions
+	i.opt.metered = options.Budget != 0 || options.BudgetCosts != nil
+	if options.Budget > 0 {
+		i.opt.budget = options.Budget
+	}
+	i.opt.budgetCosts = map[string]int64{}
+	for k, c := range options.BudgetCosts {
+		i.opt.budgetCosts[k] = c
+	}
+	i.frame.meter = i.newMeter(i.budget)
+	i.opt.maxCallDepth = options.MaxCallDepth
+	i.opt.goLimit = options.MaxGoroutines
+	if options.MaxAlloc > 0 {
+		i.opt.maxAlloc = options.MaxAlloc
+		i.opt.allocExec = allocExec
+	}
+	i.opt.symbolPolicy = options.SymbolPolicy
+	i.frame.allocs = i.newAllocMeter()
+	i.last = i.frame
+
 	if options.SourcecodeFilesystem != nil {
 		i.opt.filesystem = options.SourcecodeFilesystem
 	}
@@ -400,6 +588,7 @@ func New(options Options) *Interpreter {
 	// even if they are not file descriptors.
 	i.opt.specialStdio, _ = strconv.ParseBool(os.Getenv("YAEGI_SPECIAL_STDIO"))
 
+	i.initDone()
 	return &i
 }
 
@@ -492,15 +681,22 @@ func (interp *Interpreter) resizeFrame() {
 // Eval evaluates Go code represented as a string. Eval returns the last result
 // computed by the interpreter, and a non nil error in case of failure.
 func (interp *Interpreter) Eval(src string) (res reflect.Value, err error) {
-	return interp.eval(src, "", true)
+	interp.arm(interp.budget)
+	return interp.eval(src, "", true, interp.budget)
 }
 
 // EvalPath evaluates Go code located at path and returns the last result computed
 // by the interpreter, and a non nil error in case of failure.
 // The main function of the main package is executed if present.
 func (interp *Interpreter) EvalPath(path string) (res reflect.Value, err error) {
+	interp.arm(interp.budget)
+	return interp.evalPath(path, interp.budget)
+}
+
+func (interp *Interpreter) evalPath(path string, budget int64) (res reflect.Value, err error) {
 	if !isFile(interp.opt.filesystem, path) {
-		_, err := interp.importSrc(mainID, path, NoTest)
+		defer interp.catchBudget(&err)
+		_, err = interp.importSrc(mainID, path, NoTest)
 		return res, err
 	}
 
@@ -508,7 +704,7 @@ func (interp *Interpreter) EvalPath(path string) (res reflect.Value, err error)
 	if err != nil {
 		return res, err
 	}
-	return interp.eval(string(b), path, false)
+	return interp.eval(string(b), path, false, budget)
 }
 
 // EvalPathWithContext evaluates Go code located at path and returns the last
@@ -516,14 +712,15 @@ func (interp *Interpreter) EvalPath(path string) (res reflect.Value, err error)
 // The main function of the main package is executed if present.
 func (interp *Interpreter) EvalPathWithContext(ctx context.Context, path string) (res reflect.Value, err error) {
 	interp.mutex.Lock()
-	interp.done = make(chan struct{})
-	interp.cancelChan = !interp.opt.fastChan
+	interp.renewDone()
 	interp.mutex.Unlock()
 
+	budget := interp.budgetOf(ctx)
+	interp.arm(budget)
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		res, err = interp.EvalPath(path)
+		res, err = interp.evalPath(path, budget)
 	}()
 
 	select {
@@ -539,8 +736,10 @@ func (interp *Interpreter) EvalPathWithContext(ctx context.Context, path string)
 // A non nil error is returned in case of failure.
 // The main function, test functions and benchmark functions are internally compiled but not
 // executed. Test functions can be retrieved using the Symbol() method.
-func (interp *Interpreter) EvalTest(path string) error {
-	_, err := interp.importSrc(mainID, path, Test)
+func (interp *Interpreter) EvalTest(path string) (err error) {
+	interp.arm(interp.budget)
+	defer interp.catchBudget(&err)
+	_, err = interp.importSrc(mainID, path, Test)
 	return err
 }
 
@@ -605,8 +804,8 @@ func isFile(filesystem fs.FS, path string) bool {
 	return err == nil && fi.Mode().IsRegular()
 }
 
-func (interp *Interpreter) eval(src, name string, inc bool) (res reflect.Value, err error) {
-	prog, err := interp.compileSrc(src, name, inc)
+func (interp *Interpreter) eval(src, name string, inc bool, budget int64) (res reflect.Value, err error) {
+	prog, err := interp.compile(src, name, inc)
 	if err != nil {
 		return res, err
 	}
@@ -615,7 +814,15 @@ func (interp *Interpreter) eval(src, name string, inc bool) (res reflect.Value,
 		return res, err
 	}
 
-	return interp.Execute(prog)
+	return interp.execute(prog, budget)
+}
+
+// compile is like compileSrc, but returns an *ErrBudgetExceeded or an
+// *ErrAllocExceeded error if the execution of the imported packages exceeds
+// the budget or the allocation limit.
+func (interp *Interpreter) compile(src, name string, inc bool) (prog *Program, err error) {
+	defer interp.catchBudget(&err)
+	return interp.compileSrc(src, name, inc)
 }
 
 // EvalWithContext evaluates Go code represented as a string. It returns
@@ -625,10 +832,11 @@ func (interp *Interpreter) EvalWithContext(ctx context.Context, src string) (ref
 	var err error
 
 	interp.mutex.Lock()
-	interp.done = make(chan struct{})
-	interp.cancelChan = !interp.opt.fastChan
+	interp.renewDone()
 	interp.mutex.Unlock()
 
+	budget := interp.budgetOf(ctx)
+	interp.arm(budget)
 	done := make(chan struct{})
 	go func() {
 		defer func() {
@@ -639,7 +847,7 @@ func (interp *Interpreter) EvalWithContext(ctx context.Context, src string) (ref
 			}
 			close(done)
 		}()
-		v, err = interp.Eval(src)
+		v, err = interp.eval(src, "", true, budget)
 	}()
 
 	select {
@@ -682,6 +890,7 @@ func (interp *Interpreter) Use(values Exports) error {
 
 		if importPath == selfPrefix {
 			interp.hooks.Parse(v)
+			interp.conv.reset()
 			continue
 		}
 
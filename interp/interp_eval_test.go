@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"go/build"
 	"go/parser"
@@ -1850,3 +1851,1346 @@ func TestIssue1383(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type testAmount struct{ Cents int64 }
+
+func TestEvalConvertHook(t *testing.T) {
+	amountType := reflect.TypeOf(testAmount{})
+	var calls int
+	convert := func(from, to reflect.Type) func(src, dest reflect.Value) {
+		calls++
+		if from.Kind() != reflect.String || to != amountType {
+			return nil
+		}
+		return func(src, dest reflect.Value) {
+			f, err := strconv.ParseFloat(src.String(), 64)
+			if err != nil {
+				panic(err)
+			}
+			dest.Set(reflect.ValueOf(testAmount{Cents: int64(f*100 + 0.5)}))
+		}
+	}
+
+	i := interp.New(interp.Options{})
+	if err := i.Use(interp.Exports{
+		"github.com/traefik/yaegi/yaegi": {"convert": reflect.ValueOf(convert)},
+		"amount/amount": {
+			"Amount": reflect.ValueOf((*testAmount)(nil)),
+			"Double": reflect.ValueOf(func(a testAmount) testAmount { return testAmount{a.Cents * 2} }),
+			"Sum": reflect.ValueOf(func(m map[string]testAmount) (s int64) {
+				for _, a := range m {
+					s += a.Cents
+				}
+				return s
+			}),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "amount"`)
+	eval(t, i, `var a amount.Amount = "1.25"`)
+	eval(t, i, `var values = map[string]interface{}{"k": "0.10"}`)
+	eval(t, i, `var x interface{} = amount.Amount{Cents: 125}`)
+
+	runTests(t, i, []testCase{
+		{desc: "assign", src: `a.Cents`, res: "125"},
+		{desc: "argument", src: `amount.Double("2.50").Cents`, res: "500"},
+		{desc: "map", src: `amount.Sum(values)`, res: "10"},
+		{desc: "compare", src: `x == "1.25"`, res: "true"},
+	})
+
+	n := calls
+	if _, err := i.Eval(`amount.Double("1.00").Cents`); err != nil {
+		t.Fatal(err)
+	}
+	if calls != n {
+		t.Errorf("converter lookup not cached: got %d calls, want %d", calls, n)
+	}
+}
+
+func TestEvalConversionFailure(t *testing.T) {
+	const src = `func f() (r int) {
+	defer func() {
+		if e := recover(); e != nil {
+			r = -1
+		}
+	}()
+	s := "abc"
+	r = s
+	return r
+}`
+
+	tests := []struct {
+		desc   string
+		policy interp.ConversionFailure
+		res    string
+		err    string
+	}{
+		{desc: "panic", policy: interp.ConversionFailPanic, res: "-1"},
+		{desc: "error", policy: interp.ConversionFailError, err: `8:2 failed to convert string to int: unable to cast "abc" of type string to int64`},
+		{desc: "zero", policy: interp.ConversionFailZero, res: "0"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			i := interp.New(interp.Options{ConversionFailure: test.policy, Stderr: &bytes.Buffer{}})
+			eval(t, i, src)
+			res, err := i.Eval(`f()`)
+			if test.err != "" {
+				var ce *interp.ConversionError
+				if !errors.As(err, &ce) {
+					t.Fatalf("got %v, want a *interp.ConversionError", err)
+				}
+				if ce.Error() != test.err {
+					t.Errorf("got %q, want %q", ce.Error(), test.err)
+				}
+				if ce.Source != reflect.TypeOf("") || ce.Target != reflect.TypeOf(0) || ce.Value != "abc" {
+					t.Errorf("unexpected conversion error content: %#v", ce)
+				}
+				if ce.Pos().Line != 8 {
+					t.Errorf("got line %d, want 8", ce.Pos().Line)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := fmt.Sprintf("%v", res); s != test.res {
+				t.Errorf("got %s, want %s", s, test.res)
+			}
+		})
+	}
+}
+
+func TestEvalConversionFailureOperator(t *testing.T) {
+	i := interp.New(interp.Options{Stderr: &bytes.Buffer{}})
+	eval(t, i, `var x interface{} = "a"`)
+	eval(t, i, `var y interface{} = 1`)
+
+	conversionPanic := func(src string) *interp.ConversionError {
+		t.Helper()
+		_, err := i.Eval(src)
+		var p interp.Panic
+		if !errors.As(err, &p) {
+			t.Fatalf("got %v, want a panic", err)
+		}
+		ce, ok := p.Value.(*interp.ConversionError)
+		if !ok {
+			t.Fatalf("got panic value %v, want a *interp.ConversionError", p.Value)
+		}
+		return ce
+	}
+
+	if ce := conversionPanic(`3 - x`); ce.Target != reflect.TypeOf(0) {
+		t.Errorf("got target %v, want int", ce.Target)
+	}
+	if ce := conversionPanic(`y - x`); ce.Unwrap().Error() != "only numbers support - operator" {
+		t.Errorf("got %q, want %q", ce.Unwrap(), "only numbers support - operator")
+	}
+
+	i = interp.New(interp.Options{ConversionFailure: interp.ConversionFailZero})
+	eval(t, i, `var x interface{} = "a"`)
+	if res := eval(t, i, `3 - x`); fmt.Sprintf("%v", res) != "3" {
+		t.Errorf("got %v, want 3", res)
+	}
+}
+
+func TestEvalConversionPolicy(t *testing.T) {
+	i := interp.New(interp.Options{ConversionPolicy: interp.ConversionPolicy{
+		TrueStrings:      []string{"yes", "on"},
+		FalseStrings:     []string{"no", "off"},
+		FloatFormat:      'f',
+		FloatPrecision:   2,
+		DecimalSeparator: ',',
+		EmptyStringZero:  true,
+	}})
+	eval(t, i, `func toBool(v interface{}) bool { b := false; b = v; return b }`)
+	eval(t, i, `func toFloat(v interface{}) float64 { f := 0.0; f = v; return f }`)
+	eval(t, i, `func toInt(v interface{}) int { n := 1; n = v; return n }`)
+	eval(t, i, `func toString(v interface{}) string { s := ""; s = v; return s }`)
+
+	runTests(t, i, []testCase{
+		{desc: "bool word", src: `toBool("Yes")`, res: "true"},
+		{desc: "condition", src: `r := 0; if toBool("off") { r = 1 }; r`, res: "0"},
+		{desc: "locale float", src: `toFloat("3,5") * 2`, res: "7"},
+		{desc: "float format", src: `toString(1.5)`, res: "1,50"},
+		{desc: "bool format", src: `toString(true)`, res: "yes"},
+		{desc: "empty string", src: `toInt("")`, res: "0"},
+	})
+}
+
+type testTruthy struct{ ok bool }
+
+func (t testTruthy) Truthy() bool { return t.ok }
+
+func TestEvalTruthy(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(interp.Exports{
+		"check/check": {
+			"Pass": reflect.ValueOf(testTruthy{true}),
+			"Fail": reflect.ValueOf(testTruthy{false}),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "check"`)
+	eval(t, i, `var result interface{} = check.Fail`)
+	eval(t, i, `type Count struct{ n int }`)
+	eval(t, i, `func (c Count) Truthy() bool { return c.n > 0 }`)
+	eval(t, i, `type Gauge struct{ n int }`)
+	eval(t, i, `func (g *Gauge) Truthy() bool { return g.n > 0 }`)
+	eval(t, i, `type Total struct{ Count }`)
+
+	runTests(t, i, []testCase{
+		{desc: "method", src: `c := Count{2}; r := 0; for j := 0; j < 3; j++ { if c { r++ } }; r`, res: "3"},
+		{desc: "pointer method", src: `g := Gauge{}; !g`, res: "true"},
+		{desc: "embedded method", src: `tot := Total{Count{1}}; !tot`, res: "false"},
+		{desc: "dynamic method", src: `c0 := interface{}(Count{0}); r := 0; if !c0 { r = 1 }; r`, res: "1"},
+		{desc: "binary if", src: `r := 0; if check.Pass { r = 1 }; r`, res: "1"},
+		{desc: "binary not", src: `!check.Fail`, res: "true"},
+		{desc: "binary and", src: `check.Pass && check.Fail`, res: "false"},
+		{desc: "binary or", src: `check.Fail || check.Pass`, res: "true"},
+		{desc: "interface", src: `r := 0; if !result { r = 2 }; r`, res: "2"},
+		{desc: "empty map", src: `!map[string]int{}`, res: "true"},
+		{desc: "string", src: `!"true"`, res: "false"},
+		{desc: "func", src: `!func() {}`, res: "false"},
+	})
+}
+
+func TestEvalDynamicAssign(t *testing.T) {
+	i := interp.New(interp.Options{})
+	eval(t, i, `var cfg interface{} = map[string]interface{}{"db": map[string]interface{}{}}`)
+	eval(t, i, `var nilMap interface{} = map[string]int(nil)`)
+	eval(t, i, `var text interface{} = "abc"`)
+
+	runTests(t, i, []testCase{
+		{desc: "selector", src: `cfg.db.port = 5432; cfg.db.port`, res: "5432"},
+		{desc: "index", src: `cfg["db"]["host"] = "local"; cfg.db.host`, res: "local"},
+		{desc: "compound", src: `cfg.db.port -= 2; cfg.db.port`, res: "5430"},
+		{desc: "inc", src: `cfg.db.port++; cfg.db.port`, res: "5431"},
+		{desc: "delete", src: `delete(cfg.db, "port"); len(cfg.db.(map[string]interface{}))`, res: "1"},
+		{desc: "nil map", src: `nilMap.a = 1`, err: "1:28 assignment to entry in nil map"},
+		{desc: "not assignable", src: `text[0] = 'b'`, err: "1:28 cannot assign to element of string"},
+		{desc: "delete non map", src: `delete(text, 0)`, err: "1:28 first argument to delete must be map; have string"},
+	})
+
+	i = interp.New(interp.Options{Strict: true})
+	eval(t, i, `var cfg interface{} = map[string]interface{}{}`)
+	runTests(t, i, []testCase{
+		{desc: "strict", src: `cfg.a = 1`, err: "1:28 undefined selector: a"},
+		{desc: "strict delete", src: `delete(cfg, "a")`, err: "1:35 first argument to delete must be map; have interface{}"},
+	})
+}
+
+func TestEvalDynamicBuiltins(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "fmt"`)
+	eval(t, i, `var doc interface{} = map[string]interface{}{"tags": []interface{}{"a", "b"}, "name": "héllo", "n": 1}`)
+	eval(t, i, `var ch interface{} = make(chan int, 4)`)
+	eval(t, i, `type T struct { Name string; count int }`)
+	eval(t, i, `var st interface{} = T{"x", 2}`)
+
+	runTests(t, i, []testCase{
+		{desc: "len map", src: `len(doc)`, res: "3"},
+		{desc: "len slice", src: `len(doc.tags)`, res: "2"},
+		{desc: "len string", src: `len(doc.name)`, res: "6"},
+		{desc: "len nil", src: `len(doc.none)`, res: "0"},
+		{desc: "len chan", src: `ch.(chan int) <- 1; len(ch)`, res: "1"},
+		{desc: "cap chan", src: `cap(ch)`, res: "4"},
+		{desc: "append", src: `doc["tags"] = append(doc.tags, "c", 4); doc.tags`, res: "[a b c 4]"},
+		{desc: "append nil", src: `doc["list"] = append(doc.list, 1); doc.list`, res: "[1]"},
+		{desc: "append ellipsis", src: `append(doc.list, []string{"x", "y"}...)`, res: "[1 x y]"},
+		{desc: "delete", src: `delete(doc, "list"); len(doc)`, res: "3"},
+		{desc: "range slice", src: `s := ""; for i, v := range doc.tags { s += fmt.Sprint(i, v) }; s`, res: "0a1b2c3 4"},
+		{desc: "range string", src: `r := []int{}; for i := range doc.name { r = append(r, i) }; r`, res: "[0 1 3 4 5]"},
+		{desc: "range struct", src: `s := ""; for k, v := range st { s += fmt.Sprint(k, v) }; s`, res: "Namexcount2"},
+		{desc: "range chan", src: `close(ch.(chan int)); n := 0; for v := range ch { n += v.(int) }; n`, res: "1"},
+		{desc: "len int", src: `len(doc.n)`, err: "1:28 invalid argument for len: int"},
+		{desc: "cap map", src: `cap(doc)`, err: "1:28 invalid argument for cap: map[string]interface {}"},
+		{desc: "append string", src: `append(doc.name, "x")`, err: "1:28 first argument to append must be slice; have string"},
+		{desc: "append ellipsis int", src: `append(doc.tags, doc.n...)`, err: "1:28 cannot use int as slice in append"},
+		{desc: "range int", src: `for range doc.n {}`, err: "1:28 cannot range over int"},
+		{desc: "range chan values", src: `for k, v := range ch { println(k, v) }`, err: "1:28 range over chan int permits only one iteration variable"},
+	})
+
+	i = interp.New(interp.Options{Strict: true})
+	eval(t, i, `var doc interface{} = []int{1}`)
+	runTests(t, i, []testCase{
+		{desc: "strict len", src: `len(doc)`, err: "1:32 invalid argument for len"},
+		{desc: "strict range", src: `for range doc {}`, err: "1:38 cannot range over interface{}"},
+	})
+}
+
+func TestEvalCoerceAssertions(t *testing.T) {
+	i := interp.New(interp.Options{CoerceAssertions: true})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "fmt"`)
+	eval(t, i, `type S struct { A int; B string }`)
+	eval(t, i, `func asInt(v interface{}) string { r, ok := v.(int); return fmt.Sprintf("%v %v", r, ok) }`)
+	eval(t, i, `func asUint8(v interface{}) string { r, ok := v.(uint8); return fmt.Sprintf("%v %v", r, ok) }`)
+	eval(t, i, `func asFloat32(v interface{}) string { r, ok := v.(float32); return fmt.Sprintf("%v %v", r, ok) }`)
+	eval(t, i, `func asString(v interface{}) string { r, ok := v.(string); return fmt.Sprintf("%v %v", r, ok) }`)
+	eval(t, i, `func asStringer(v interface{}) bool { _, ok := v.(fmt.Stringer); return ok }`)
+
+	runTests(t, i, []testCase{
+		{desc: "string to int", src: `x := interface{}("42"); x.(int)`, res: "42"},
+		{desc: "float to int", src: `y := interface{}(42.0); y.(int)`, res: "42"},
+		{desc: "int to string", src: `z := interface{}(7); z.(string)`, res: "7"},
+		{desc: "nil to int", src: `n := interface{}(nil); n.(int)`, res: "0"},
+		{desc: "slice", src: `l := interface{}([]interface{}{"1", 2.0}); l.([]int)`, res: "[1 2]"},
+		{desc: "map", src: `m := interface{}(map[string]interface{}{"a": "1"}); m.(map[string]int)`, res: "map[a:1]"},
+		{desc: "struct", src: `s := interface{}(map[string]interface{}{"A": 3, "B": "b"}); s.(S)`, res: "{3 b}"},
+		{desc: "failure", src: `e := interface{}("abc"); e.(int)`, err: `1:53 failed to convert string to int: unable to cast "abc" of type string to int64`},
+
+		{desc: "ok identical", src: `asInt(3)`, res: "3 true"},
+		{desc: "ok numeric string", src: `asInt("42")`, res: "42 true"},
+		{desc: "ok integral float", src: `asInt(42.0)`, res: "42 true"},
+		{desc: "ok integral float string", src: `asInt("42.0")`, res: "42 true"},
+		{desc: "ok fractional", src: `asInt(4.2)`, res: "0 false"},
+		{desc: "ok fractional string", src: `asInt("4.2")`, res: "0 false"},
+		{desc: "ok not a number", src: `asInt("abc")`, res: "0 false"},
+		{desc: "ok nil", src: `asInt(nil)`, res: "0 false"},
+		{desc: "ok overflow", src: `asUint8(300)`, res: "0 false"},
+		{desc: "ok precision", src: `asFloat32(0.1)`, res: "0 false"},
+		{desc: "ok exact float32", src: `asFloat32(0.5)`, res: "0.5 true"},
+		{desc: "ok bool to string", src: `asString(true)`, res: "true true"},
+		{desc: "ok interface", src: `asStringer(5)`, res: "false"},
+	})
+
+	i = interp.New(interp.Options{})
+	eval(t, i, `var x interface{} = "42"`)
+	runTests(t, i, []testCase{
+		{desc: "disabled", src: `x.(int)`, err: "interface conversion: interface {} is string, not int"},
+	})
+}
+
+func TestEvalImplicitConversionSites(t *testing.T) {
+	i := interp.New(interp.Options{})
+	eval(t, i, `type T struct { N int; S string }`)
+	eval(t, i, `type Stringer interface { String() string }`)
+	eval(t, i, `type Num string`)
+	eval(t, i, `func (n Num) String() string { return string(n) }`)
+	eval(t, i, `func ret(v interface{}) int { return v }`)
+	eval(t, i, `func ret2(v interface{}) (int, string) { return v, v }`)
+	eval(t, i, `func retStringer(s Stringer) string { return s }`)
+	eval(t, i, `func send(v interface{}) int { ch := make(chan int, 1); ch <- v; r := <-ch; return r }`)
+	eval(t, i, `func typed(v interface{}) int { var n int = v; return n }`)
+	eval(t, i, `func has(m map[int]string, k interface{}) bool { _, ok := m[k]; return ok }`)
+	eval(t, i, `func concat(s string) int { return s + "1" }`)
+	eval(t, i, `func lit(v interface{}) T { var t T = map[string]interface{}{"N": v, "S": 2}; return t }`)
+
+	runTests(t, i, []testCase{
+		{desc: "return", src: `ret("42")`, res: "42"},
+		{desc: "return multiple", src: `a, b := ret2(3.0); b + "/" + string(rune('0' + a))`, res: "3/3"},
+		{desc: "return interpreted interface", src: `retStringer(Num("12"))`, res: "12"},
+		{desc: "return incompatible", src: `func bad() int { return struct{}{} }`, err: "1:38 cannot use type struct {} as type int in return argument"},
+		{desc: "send", src: `send("12")`, res: "12"},
+		{desc: "send non-channel", src: `c := 3; c <- 1`, err: "1:36 invalid operation: cannot send to non-channel int"},
+		{desc: "typed var", src: `typed("5")`, res: "5"},
+		{desc: "typed var operation", src: `s1 := "1"; var n1 int = s1 + "2"; n1`, res: "12"},
+		{desc: "assigned operation", src: `s2 := "3"; n2 := 0; n2 = s2 + s2; n2`, res: "33"},
+		{desc: "returned operation", src: `concat("4")`, res: "41"},
+		{desc: "typed var literal", src: `lit("5")`, res: "{5 2}"},
+		{desc: "map index", src: `m := map[int]string{1: "one", 2: "two"}; k := interface{}("2"); m[k] + m["1"]`, res: "twoone"},
+		{desc: "map index comma ok", src: `has(map[int]string{2: "two"}, 2.0)`, res: "true"},
+		{desc: "map assign", src: `m3 := map[string]int{}; m3[4] = 4; m3["4"]`, res: "4"},
+		{desc: "map delete", src: `m4 := map[int]int{3: 3}; delete(m4, "3"); len(m4)`, res: "0"},
+		{desc: "struct literal", src: `x := interface{}("42"); y := interface{}(7); T{N: x, S: y}`, res: "{42 7}"},
+		{desc: "struct literal unkeyed", src: `x1 := interface{}("42"); T{x1, "s"}`, res: "{42 s}"},
+		{desc: "slice literal", src: `x3 := interface{}("1"); []int{x3, 2}`, res: "[1 2]"},
+		{desc: "map literal", src: `x4 := interface{}("1"); map[string]int{"a": x4}`, res: "map[a:1]"},
+	})
+
+	// A panic in a function replays in the following evaluations, so each
+	// failure is evaluated by a new interpreter.
+	for _, test := range []testCase{
+		{desc: "return failure", pre: func() { eval(t, i, `func fail(v interface{}) int { return v }`) }, src: `fail("abc")`, err: `1:52 failed to convert string to int: unable to cast "abc" of type string to int64`},
+		{desc: "send failure", pre: func() { eval(t, i, `func fail(v interface{}) { ch := make(chan int, 1); ch <- v }`) }, src: `fail("abc")`, err: `1:72 failed to convert string to int: unable to cast "abc" of type string to int64`},
+		{desc: "struct literal failure", pre: func() { eval(t, i, `func fail(v interface{}) interface{} { return struct{ N int }{N: v} }`) }, src: `fail("abc")`, err: `1:79 failed to convert string to int: unable to cast "abc" of type string to int64`},
+	} {
+		i = interp.New(interp.Options{})
+		runTests(t, i, []testCase{test})
+	}
+
+	i = interp.New(interp.Options{Strict: true})
+	runTests(t, i, []testCase{
+		{desc: "strict return", src: `func sret(v string) int { return v }`, err: "1:47 cannot use v (type string) as type int in return argument"},
+		{desc: "strict send", src: `func ssend(v string) { ch := make(chan int, 1); ch <- v }`, err: "1:68 cannot use type string as type int in send"},
+	})
+}
+
+func TestEvalNilSafe(t *testing.T) {
+	const event = `var event interface{} = map[string]interface{}{
+	"user": map[string]interface{}{"id": 7, "name": "bob", "tags": []interface{}{"a"}, "address": nil},
+}`
+
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, event)
+	eval(t, i, `import "time"`)
+	eval(t, i, `var tm interface{} = time.Time{}`)
+	eval(t, i, `type U struct{ Name string }`)
+	eval(t, i, `var u interface{} = U{"ann"}`)
+	eval(t, i, `var nu interface{} = (*U)(nil)`)
+	eval(t, i, `var ids interface{} = map[int]string{1: "one"}`)
+	runTests(t, i, []testCase{
+		{desc: "struct field", src: `u.Name`, res: "ann"},
+		{desc: "undefined field", src: `u.Age`, res: "<nil>"},
+		{desc: "nil pointer", src: `nu.Name`, res: "<nil>"},
+		{desc: "unexported field", src: `tm.wall`, err: "1:28 cannot access unexported field wall of time.Time"},
+		{desc: "unconvertible key", src: `ids.name`, err: "1:28 failed to convert string to int: unable to cast \"name\" of type string to int64"},
+		{desc: "missing key", src: `event.user.age`, res: "<nil>"},
+		{desc: "missing key reset", src: `r := event.user.name; r = event.user.age; r`, res: "<nil>"},
+		{desc: "nil value", src: `event.user.address.city`, res: "<nil>"},
+		{desc: "out of range", src: `event.user.tags[2]`, err: "1:28 index out of range [2] with length 1"},
+		{desc: "invalid index", src: `event.user.tags.name`, res: "<nil>"},
+		{desc: "not a container", src: `event.user.id.x`, res: "<nil>"},
+		{desc: "condition", src: `r := 0; if event.user.tags { r = 1 }; if event.user.age { r = 2 }; r`, res: "1"},
+	})
+
+	i = interp.New(interp.Options{NilSafe: true})
+	eval(t, i, event)
+	eval(t, i, `type T struct{ Name string }`)
+	eval(t, i, `var p interface{} = (*T)(nil)`)
+	eval(t, i, `var ids interface{} = map[int]string{1: "one"}`)
+	runTests(t, i, []testCase{
+		{desc: "deep path", src: `event.user.address.city`, res: "<nil>"},
+		{desc: "missing path", src: `event.order.items[0].price`, res: "<nil>"},
+		{desc: "out of range", src: `event.user.tags[2]`, res: "<nil>"},
+		{desc: "not indexable", src: `event.user.name.first`, res: "<nil>"},
+		{desc: "nil pointer", src: `p.Name`, res: "<nil>"},
+		{desc: "existing path", src: `event.user.tags[0]`, res: "a"},
+		{desc: "unconvertible key", src: `ids.name`, res: "<nil>"},
+		{desc: "converted key", src: `ids["1"]`, res: "one"},
+		{desc: "condition", src: `r := "none"; if event.user.address.city { r = "some" }; r`, res: "none"},
+	})
+}
+
+func TestEvalDynamicOperators(t *testing.T) {
+	operands := map[string]string{
+		"i": `7`, "i8": `int8(-2)`, "u": `uint(3)`, "f": `2.5`, "f32": `float32(0.5)`, "c": `complex(1, 1)`,
+		"n": `"4"`, "nf": `"1.5"`, "s": `"x"`, "b": `true`, "z": `nil`, "p": `[]int{1}`,
+	}
+	i := interp.New(interp.Options{Stderr: &bytes.Buffer{}})
+	for name, value := range operands {
+		eval(t, i, "var "+name+" interface{} = "+value)
+	}
+
+	// Each result is formatted as "value type", or as the reason of the runtime error.
+	tests := []struct{ src, res string }{
+		// Numeric promotion.
+		{`i + i`, "14 int"},
+		{`i8 + i8`, "-4 int8"},
+		{`i + i8`, "5 int64"},
+		{`i - u`, "4 int64"},
+		{`u - i`, "-4 int64"},
+		{`u * u`, "9 uint"},
+		{`i / f`, "2.8 float64"},
+		{`f32 * f32`, "0.25 float32"},
+		{`f32 + i`, "7.5 float64"},
+		{`c * c`, "(0+2i) complex128"},
+		{`c + i`, "(8+1i) complex128"},
+		{`i / u`, "2 int64"},
+		{`i % f`, "1 int64"},
+		{`f % f32`, "integer divide by zero"},
+		{`c % i`, "failed to convert complex128 to int: only integers support % operator"},
+
+		// Strings, numeric strings, bool and nil.
+		{`i + n`, "11 int64"},
+		{`n + nf`, "41.5 string"},
+		{`n * nf`, "6 float64"},
+		{`s + i`, "x7 string"},
+		{`i + s`, "7x string"},
+		{`s + b`, "xtrue string"},
+		{`s - i`, "failed to convert string to int: only numbers support - operator"},
+		{`p * i`, "failed to convert []int to int: only numbers support * operator"},
+		{`b + b`, "2 int"},
+		{`i - b`, "6 int"},
+		{`z + i`, "7 int"},
+
+		// Bitwise operators and shifts.
+		{`i & u`, "3 int64"},
+		{`u | u`, "3 uint"},
+		{`i ^ n`, "3 int64"},
+		{`i &^ f`, "5 int64"},
+		{`i << u`, "56 int"},
+		{`u << n`, "48 uint"},
+		{`i8 >> b`, "-1 int8"},
+		{`n >> u`, "0 int64"},
+		{`i << i8`, "negative shift amount"},
+		{`i / z`, "integer divide by zero"},
+
+		// Unary operators.
+		{`-i`, "-7 int"},
+		{`-nf`, "-1.5 float64"},
+		{`+n`, "4 int64"},
+		{`^i8`, "1 int8"},
+		{`^f`, "-3 int64"},
+		{`-b`, "-1 int"},
+		{`-s`, "failed to convert string to int: only numbers support - operator"},
+
+		// Comparisons.
+		{`i == n`, "false bool"},
+		{`i > n`, "true bool"},
+		{`n < nf`, "false bool"},
+		{`u >= i8`, "true bool"},
+		{`f32 < f`, "true bool"},
+		{`i == "7"`, "true bool"},
+		{`i < s`, "true bool"},
+		{`s > n`, "true bool"},
+		{`b != 1`, "false bool"},
+		{`b < b`, "invalid operation: operator < not defined on bool"},
+		{`z == z`, "true bool"},
+		{`z == 0`, "false bool"},
+		{`z != p`, "true bool"},
+		{`c == c`, "true bool"},
+		{`c < c`, "invalid operation: operator < not defined on complex128"},
+		{`p == p`, "comparing uncomparable type []int"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.src, func(t *testing.T) {
+			res, err := i.Eval(test.src)
+			var got string
+			if err != nil {
+				got = dynamicOperatorError(t, test.src, err)
+			} else {
+				got = fmt.Sprintf("%v %T", res, res.Interface())
+			}
+			if got != test.res {
+				t.Errorf("got %q, want %q", got, test.res)
+			}
+		})
+	}
+
+	// Every operator applies to every pair of dynamic operands, either with
+	// a result or with an interpreter error, never with a raw runtime panic.
+	binary := []string{"+", "-", "*", "/", "%", "&", "|", "^", "&^", "<<", ">>", "==", "!=", "<", "<=", ">", ">="}
+	for x := range operands {
+		for _, op := range []string{"-", "+", "^"} {
+			if _, err := i.Eval(op + x); err != nil {
+				dynamicOperatorError(t, op+x, err)
+			}
+		}
+		for y := range operands {
+			for _, op := range binary {
+				src := x + " " + op + " " + y
+				if _, err := i.Eval(src); err != nil {
+					dynamicOperatorError(t, src, err)
+				}
+			}
+		}
+	}
+}
+
+// dynamicOperatorError returns the reason of an interpreter runtime error,
+// and fails the test on any other error.
+func dynamicOperatorError(t *testing.T, src string, err error) string {
+	t.Helper()
+	var p interp.Panic
+	if errors.As(err, &p) {
+		if e, ok := p.Value.(interface{ Reason() string }); ok {
+			return e.Reason()
+		}
+	}
+	t.Errorf("%s: unexpected error %v", src, err)
+	return err.Error()
+}
+
+func TestAnalyze(t *testing.T) {
+	src := `package main
+
+import "fmt"
+
+type T struct{ N int }
+
+func f(x interface{}) int {
+	return x
+}
+
+func main() {
+	n := "3"
+	var i int = n
+	if i {
+		fmt.Println(T{N: n}, !n, i+f(n) == n)
+	}
+	var x interface{} = map[string]interface{}{"a": 1}
+	fmt.Println(x.a, x["a"])
+	panic("not run")
+}
+`
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"8:9: return argument: interface{} -> int",
+		"13:14: assignment: string -> int",
+		"14:5: condition: int -> bool",
+		"15:20: struct literal: string -> int",
+		"15:25: operator !: string -> bool",
+		"15:28: operator ==: int, string -> bool",
+		"18:14: dynamic selector: interface{} -> interface{}",
+		"18:19: dynamic index: interface{} -> interface{}",
+	}
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	convs, err := i.Analyze(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range convs {
+		got = append(got, strings.TrimPrefix(c.String(), path+":"))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Strict semantics reject the conversions rather than report them.
+	i = interp.New(interp.Options{Strict: true})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.Analyze(path); err == nil {
+		t.Error("got nil error in strict mode")
+	}
+}
+
+func TestAnalyzeImport(t *testing.T) {
+	gopath := t.TempDir()
+	dir := filepath.Join(gopath, "src", "example.com", "p")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\nvar X = f()\n\nfunc f() int { return 42 }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(gopath, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n\nimport \"example.com/p\"\n\nfunc main() { println(p.X) }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The packages compiled by Analyze are not registered, and are
+	// initialized when imported afterwards.
+	i := interp.New(interp.Options{GoPath: gopath})
+	if _, err := i.Analyze(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.Eval(`import "example.com/p"`); err != nil {
+		t.Fatal(err)
+	}
+	res, err := i.Eval("p.X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Interface() != 42 {
+		t.Errorf("got %v, want 42", res)
+	}
+}
+
+func TestEvalExpr(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "strings"`)
+	eval(t, i, `var count = 1`)
+	eval(t, i, `func double(n int) int { return 2 * n }`)
+	globals := fmt.Sprint(i.Globals())
+
+	type point struct{ X, Y int }
+	tests := []struct {
+		src  string
+		vars map[string]interface{}
+		res  string
+		err  string
+	}{
+		{src: `a + b`, vars: map[string]interface{}{"a": 1, "b": 2}, res: "3 int"},
+		{src: `a + b`, vars: map[string]interface{}{"a": 3, "b": 4}, res: "7 int"},
+		{src: `a + b`, vars: map[string]interface{}{"a": "x", "b": "y"}, res: "xy string"},
+		{src: `double(n) + count`, vars: map[string]interface{}{"n": 4}, res: "9 int"},
+		{src: `strings.ToUpper(s)`, vars: map[string]interface{}{"s": "ok"}, res: "OK string"},
+		{src: `p.X * p.Y`, vars: map[string]interface{}{"p": point{2, 3}}, res: "6 int"},
+		{src: `m["k"] + 1`, vars: map[string]interface{}{"m": map[string]int{"k": 41}}, res: "42 int"},
+		{src: `v == nil`, vars: map[string]interface{}{"v": nil}, res: "true bool"},
+		{src: `func() int { return a * 2 }()`, vars: map[string]interface{}{"a": 21}, res: "42 int"},
+		{src: `len(l) > 2`, vars: map[string]interface{}{"l": []string{"a"}}, res: "false bool"},
+		{src: `v`, vars: map[string]interface{}{"v": nil}, res: "<invalid Value>"},
+		{src: `a +`, err: "_.go:1:4: expected operand, found 'EOF'"},
+		{src: `a + 1`, err: "1:1 undefined: a"},
+		{src: `a / b`, vars: map[string]interface{}{"a": 1, "b": 0}, err: "runtime error: integer divide by zero"},
+		{src: `a`, vars: map[string]interface{}{"a-b": 1}, err: `invalid variable name: "a-b"`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.src, func(t *testing.T) {
+			res, err := i.EvalExpr(test.src, test.vars)
+			if test.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := res.String()
+			if res.IsValid() {
+				got = fmt.Sprintf("%v %T", res, res.Interface())
+			}
+			if got != test.res {
+				t.Errorf("got %q, want %q", got, test.res)
+			}
+		})
+	}
+	if g := fmt.Sprint(i.Globals()); g != globals {
+		t.Errorf("globals changed from %s to %s", globals, g)
+	}
+
+	// A compiled expression is evaluated many times, with values converted
+	// to the types of the variables.
+	e, err := i.CompileExpr(`n * 2`, map[string]reflect.Type{"n": reflect.TypeOf(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for in, want := range map[interface{}]int{1: 2, "21": 42, 2.0: 4} {
+		res, err := e.Eval(map[string]interface{}{"n": in})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Interface(); got != want {
+			t.Errorf("n = %v: got %v, want %v", in, got, want)
+		}
+	}
+	if _, err := e.Eval(nil); err == nil || err.Error() != "n * 2: missing value for variable n" {
+		t.Errorf("got error %v, want missing value", err)
+	}
+
+	i = interp.New(interp.Options{Strict: true})
+	if e, err = i.CompileExpr(`n * 2`, map[string]reflect.Type{"n": reflect.TypeOf(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Eval(map[string]interface{}{"n": "21"}); err == nil || err.Error() != "n * 2: variable n: cannot use string as int" {
+		t.Errorf("got error %v, want cannot use string as int", err)
+	}
+}
+
+func TestBind(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `func add(a, b int) int { return a + b }`)
+	eval(t, i, `func sum(l ...int) (n int) { for _, v := range l { n += v }; return }`)
+	eval(t, i, `func hello(name string) (string, error) { return "hello " + name, nil }`)
+	eval(t, i, `var twice = func(s string) string { return s + s }`)
+
+	var add func(int, int) int
+	if err := i.Bind("add", &add); err != nil {
+		t.Fatal(err)
+	}
+	if got := add(2, 3); got != 5 {
+		t.Errorf("add: got %v, want 5", got)
+	}
+
+	var addMain func(int, int) int
+	if err := i.Bind("main.add", &addMain); err != nil {
+		t.Fatal(err)
+	}
+	if got := addMain(1, 1); got != 2 {
+		t.Errorf("main.add: got %v, want 2", got)
+	}
+
+	// The arguments and results are converted.
+	var addLoose func(string, float64) string
+	if err := i.Bind("add", &addLoose); err != nil {
+		t.Fatal(err)
+	}
+	if got := addLoose("40", 2); got != "42" {
+		t.Errorf("add: got %q, want 42", got)
+	}
+
+	var sum3 func(int, int, int) int
+	if err := i.Bind("sum", &sum3); err != nil {
+		t.Fatal(err)
+	}
+	if got := sum3(1, 2, 3); got != 6 {
+		t.Errorf("sum: got %v, want 6", got)
+	}
+
+	var sumAll func(...int64) int64
+	if err := i.Bind("sum", &sumAll); err != nil {
+		t.Fatal(err)
+	}
+	if got := sumAll(1, 2, 3, 4); got != 10 {
+		t.Errorf("sum: got %v, want 10", got)
+	}
+
+	var hello func(string) (string, error)
+	if err := i.Bind("hello", &hello); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := hello("bob"); err != nil || got != "hello bob" {
+		t.Errorf("hello: got %q, %v, want hello bob", got, err)
+	}
+
+	var twice func(string) string
+	if err := i.Bind("twice", &twice); err != nil {
+		t.Fatal(err)
+	}
+	if got := twice("ab"); got != "abab" {
+		t.Errorf("twice: got %q, want abab", got)
+	}
+
+	var upper func(string) string
+	if err := i.Bind("strings.ToUpper", &upper); err != nil {
+		t.Fatal(err)
+	}
+	if got := upper("ok"); got != "OK" {
+		t.Errorf("strings.ToUpper: got %q, want OK", got)
+	}
+
+	var bad func(int) int
+	var badVariadic func(...int) int
+	var badResults func(int, int)
+	for _, test := range []struct {
+		name  string
+		fnPtr interface{}
+		err   string
+	}{
+		{name: "add", fnPtr: add, err: "bind add: want a non-nil pointer to a function variable, got func(int, int) int"},
+		{name: "missing", fnPtr: &bad, err: "bind missing: undefined function"},
+		{name: "add", fnPtr: &bad, err: "bind add: func(int) int has 1 parameters, want 2"},
+		{name: "add", fnPtr: &badVariadic, err: "bind add: cannot use variadic func(...int) int as func(int, int) int"},
+		{name: "add", fnPtr: &badResults, err: "bind add: func(int, int) has 0 results, want 1"},
+	} {
+		if err := i.Bind(test.name, test.fnPtr); err == nil || err.Error() != test.err {
+			t.Errorf("got error %v, want %q", err, test.err)
+		}
+	}
+
+	// A failed conversion panics at call time.
+	func() {
+		defer func() {
+			if r := recover(); r == nil || fmt.Sprint(r) != `add: argument 0: unable to cast "x" of type string to int64` {
+				t.Errorf("got panic %v", r)
+			}
+		}()
+		addLoose("x", 1)
+	}()
+
+	i = interp.New(interp.Options{Strict: true})
+	eval(t, i, `func add(a, b int) int { return a + b }`)
+	if err := i.Bind("add", &addLoose); err == nil || err.Error() != "bind add: cannot use string as int in parameter 0" {
+		t.Errorf("got error %v, want cannot use string as int", err)
+	}
+}
+
+func TestEvalInto(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "strconv"`)
+	eval(t, i, `func pair() (int, string) { return 7, "seven" }`)
+	eval(t, i, `func double(n int) int { return 2 * n }`)
+	eval(t, i, `func noop() {}`)
+
+	var n int
+	if err := i.EvalInto(`double(21)`, &n); err != nil || n != 42 {
+		t.Errorf("got %v, %v, want 42", n, err)
+	}
+
+	// The result is converted to the type of the destination.
+	var s string
+	if err := i.EvalInto(`40 + 2`, &s); err != nil || s != "42" {
+		t.Errorf("got %q, %v, want 42", s, err)
+	}
+	var f float64
+	if err := i.EvalInto(`x := "1.5"; x`, &f); err != nil || f != 1.5 {
+		t.Errorf("got %v, %v, want 1.5", f, err)
+	}
+
+	var fn func(int) int
+	if err := i.EvalInto(`double`, &fn); err != nil || fn(4) != 8 {
+		t.Errorf("got %v, want a function doubling its argument", err)
+	}
+
+	var name string
+	n = 0
+	if err := i.EvalInto(`pair()`, []interface{}{&n, &name}); err != nil || n != 7 || name != "seven" {
+		t.Errorf("got %v, %q, %v, want 7, seven", n, name, err)
+	}
+	var err2 error
+	if err := i.EvalInto(`strconv.Atoi("12")`, []interface{}{&s, &err2}); err != nil || s != "12" || err2 != nil {
+		t.Errorf("got %q, %v, %v, want 12, nil", s, err2, err)
+	}
+
+	for _, test := range []struct {
+		src  string
+		dest interface{}
+		err  string
+	}{
+		{src: `pair()`, dest: &n, err: "assignment mismatch: 1 destinations but 2 values"},
+		{src: `double(1)`, dest: []interface{}{&n, &s}, err: "assignment mismatch: 2 destinations but 1 values"},
+		{src: `double(1)`, dest: n, err: "destination 0: want a non-nil pointer, got int"},
+		{src: `noop()`, dest: &n, err: "assignment mismatch: 1 destinations but 0 values"},
+		{src: `import "strings"`, dest: &n, err: "assignment mismatch: 1 destinations but 0 values"},
+		{src: `"x"`, dest: &n, err: `destination 0: unable to cast "x" of type string to int64`},
+		{src: `double()`, dest: &n, err: "1:28 not enough arguments in call to double"},
+	} {
+		if err := i.EvalInto(test.src, test.dest); err == nil || err.Error() != test.err {
+			t.Errorf("%s: got error %v, want %q", test.src, err, test.err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := i.EvalIntoWithContext(ctx, `for {}`, &n); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := i.EvalIntoWithContext(context.Background(), `double(5)`, &n); err != nil || n != 10 {
+		t.Errorf("got %v, %v, want 10", n, err)
+	}
+
+	i = interp.New(interp.Options{Strict: true})
+	if err := i.EvalInto(`40 + 2`, &s); err == nil || err.Error() != "destination 0: cannot use int as string" {
+		t.Errorf("got error %v, want cannot use int as string", err)
+	}
+}
+
+func TestPackageInfo(t *testing.T) {
+	i := interp.New(interp.Options{GoPath: filepath.FromSlash("../_test/testdata/introspect")})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import "guthib.com/shape"`)
+	eval(t, i, `func area(s shape.Shape) float64 { return s.Area() }`)
+
+	if got, want := i.SourcePackages(), []string{"guthib.com/shape", "main"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got packages %v, want %v", got, want)
+	}
+	if _, err := i.Package("guthib.com/missing"); err == nil || err.Error() != "package guthib.com/missing not loaded" {
+		t.Errorf("got error %v, want package not loaded", err)
+	}
+
+	info, err := i.Package("guthib.com/shape")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "shape" {
+		t.Errorf("got name %q, want shape", info.Name)
+	}
+	if got := fmt.Sprintf("%s %s", info.Consts[0].Name, info.Consts[0].Type); got != "Sides untyped int" {
+		t.Errorf("got const %q", got)
+	}
+	if got := fmt.Sprintf("%s %s %d:%d", info.Vars[0].Name, info.Vars[0].Type, info.Vars[0].Pos.Line, info.Vars[0].Pos.Column); got != "Count int 7:5" {
+		t.Errorf("got var %q", got)
+	}
+
+	funcs := map[string]string{}
+	for _, f := range info.Funcs {
+		funcs[f.Name] = fmt.Sprintf("%v %v %v %d:%d", f.Params, f.Results, f.Variadic, f.Pos.Line, f.Pos.Column)
+	}
+	if want := map[string]string{"New": "[float64 float64 ...string] [*shape.Rect error] true 29:6"}; !reflect.DeepEqual(funcs, want) {
+		t.Errorf("got funcs %v, want %v", funcs, want)
+	}
+
+	types := map[string]string{}
+	for _, typ := range info.Types {
+		s := typ.Kind
+		for _, f := range typ.Fields {
+			s += fmt.Sprintf(" %s:%s", f.Name, f.Type)
+			if f.Embedded {
+				s += ":embedded"
+			}
+		}
+		for _, m := range typ.Methods {
+			s += fmt.Sprintf(" %s%v%v", m.Name, m.Params, m.Results)
+			if m.PtrRecv {
+				s += ":ptr"
+			}
+		}
+		types[typ.Name] = s
+	}
+	want := map[string]string{
+		"Celsius": "float64",
+		"Named":   "struct Name:string",
+		"Rect":    "struct W:float64 H:float64 Named:shape.Named:embedded Area[][float64] Scale[float64][]:ptr String[][string]",
+		"Shape":   "interface Area[][float64] String[][string]",
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("got types %v, want %v", types, want)
+	}
+
+	main, err := i.Package("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(main.Funcs) != 1 || fmt.Sprint(main.Funcs[0].Params) != "[shape.Shape]" {
+		t.Errorf("got main funcs %+v", main.Funcs)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	i := interp.New(interp.Options{Budget: 1000, Stderr: io.Discard})
+	eval(t, i, `func fib(n int) int { if n < 2 { return n }; return fib(n-1) + fib(n-2) }`)
+
+	// The cost of an execution is deterministic.
+	eval(t, i, `fib(5)`)
+	cost := i.Cost()
+	if cost == 0 || cost > 1000 {
+		t.Fatalf("got cost %d, want in ]0, 1000]", cost)
+	}
+	eval(t, i, `fib(5)`)
+	if c := i.Cost(); c != cost {
+		t.Errorf("got cost %d, want %d", c, cost)
+	}
+
+	for _, src := range []string{
+		`for {}`,
+		`fib(30)`,
+	} {
+		_, err := i.Eval(src)
+		var e *interp.ErrBudgetExceeded
+		if !errors.As(err, &e) {
+			t.Fatalf("%s: got error %v, want budget exceeded", src, err)
+		}
+		if e.Budget != 1000 || e.Position.Line != 1 {
+			t.Errorf("%s: got budget %d at %v", src, e.Budget, e.Position)
+		}
+		if !strings.HasSuffix(err.Error(), " execution budget of 1000 exceeded") {
+			t.Errorf("%s: got error %q", src, err)
+		}
+	}
+
+	// The budget is reset by each execution.
+	if res := eval(t, i, `fib(5)`); res.Interface() != 5 {
+		t.Errorf("got %v, want 5", res)
+	}
+
+	// An exceeded budget can not be recovered.
+	if _, err := i.Eval(`func() { defer func() { recover() }(); for {} }()`); !errors.As(err, new(*interp.ErrBudgetExceeded)) {
+		t.Errorf("got error %v, want budget exceeded", err)
+	}
+
+	// A budget is given to a single execution.
+	i = interp.New(interp.Options{Budget: -1, Stderr: io.Discard})
+	eval(t, i, `func fib(n int) int { if n < 2 { return n }; return fib(n-1) + fib(n-2) }`)
+	ctx := interp.WithBudget(context.Background(), 100)
+	if _, err := i.EvalWithContext(ctx, `fib(10)`); err == nil || !strings.HasSuffix(err.Error(), "execution budget of 100 exceeded") {
+		t.Errorf("got error %v, want budget of 100 exceeded", err)
+	}
+	ctx = interp.WithBudget(context.Background(), 0)
+	if _, err := i.EvalWithContext(ctx, `fib(15)`); err != nil {
+		t.Errorf("got error %v, want no limit", err)
+	}
+
+	// Concurrent executions have their own budget.
+	eval(t, i, `var ready, gate = make(chan int), make(chan int)`)
+	ready, gate := eval(t, i, `ready`), eval(t, i, `gate`)
+	unlimited, err := i.Compile(`ready <- 1; <-gate; fib(15)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limited, err := i.Compile(`fib(10)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := i.ExecuteWithContext(interp.WithBudget(context.Background(), 0), unlimited)
+		done <- err
+	}()
+	ready.Recv()
+	if _, err := i.ExecuteWithContext(interp.WithBudget(context.Background(), 100), limited); !errors.As(err, new(*interp.ErrBudgetExceeded)) {
+		t.Errorf("got error %v, want budget exceeded", err)
+	}
+	gate.Send(reflect.ValueOf(1))
+	if err := <-done; err != nil {
+		t.Errorf("got error %v, want no limit", err)
+	}
+
+	// Node costs are configurable.
+	i = interp.New(interp.Options{Budget: 1000, BudgetCosts: map[string]int64{"callExpr": 100}, Stderr: io.Discard})
+	eval(t, i, `func f() {}`)
+	if _, err := i.Eval(`for j := 0; j < 5; j++ { f() }`); err != nil {
+		t.Error(err)
+	}
+	if _, err := i.Eval(`for j := 0; j < 10; j++ { f() }`); err == nil {
+		t.Error("got no error, want budget exceeded")
+	}
+
+	// Each run of a Runner has its own budget.
+	i = interp.New(interp.Options{Budget: 1000, Stderr: io.Discard})
+	p, err := i.Compile(`var n int; func main() { for j := 0; j < n; j++ {} }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := i.NewRunner(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{10, 10000, 10} {
+		_, _, err := r.Run(map[string]interface{}{"n": n})
+		if exceeded := errors.As(err, new(*interp.ErrBudgetExceeded)); exceeded != (n == 10000) {
+			t.Errorf("n = %d: got error %v", n, err)
+		}
+	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	i := interp.New(interp.Options{MaxCallDepth: 100, Stderr: io.Discard})
+	eval(t, i, `func fact(n int) int { if n == 0 { return 1 }; return n * fact(n-1) }`)
+	eval(t, i, `func loop(n int) int { return loop(n + 1) }`)
+
+	if res := eval(t, i, `fact(10)`); res.Interface() != 3628800 {
+		t.Errorf("got %v, want 3628800", res)
+	}
+
+	_, err := i.Eval(`loop(0)`)
+	var p interp.Panic
+	if !errors.As(err, &p) {
+		t.Fatalf("got error %v, want a Panic", err)
+	}
+	e, ok := p.Value.(*interp.CallDepthError)
+	if !ok {
+		t.Fatalf("got panic value %T, want *interp.CallDepthError", p.Value)
+	}
+	if err.Error() != "1:44 stack overflow: maximum call depth of 100 exceeded" {
+		t.Errorf("got error %q", err)
+	}
+	if len(e.Stack) != 100 || e.Stack[0].Column != 44 {
+		t.Errorf("got stack of %d calls, from %v", len(e.Stack), e.Stack[0])
+	}
+
+	// The panic is recovered by the interpreted code. The evaluated statements
+	// are run by a call to the main function.
+	eval(t, i, `func depth(n int) (d int) { defer func() { if recover() != nil { d = n } }(); return depth(n + 1) }`)
+	if res := eval(t, i, `depth(1)`); res.Interface() != 99 {
+		t.Errorf("got depth %v, want 99", res)
+	}
+
+	// The depth is counted per goroutine.
+	eval(t, i, `func deep(n int) int { if n == 0 { return 0 }; return 1 + deep(n-1) }`)
+	if res := eval(t, i, `c := make(chan int); go func() { c <- deep(90) }(); deep(90) + <-c`); res.Interface() != 180 {
+		t.Errorf("got %v, want 180", res)
+	}
+}
+
+func TestGoroutines(t *testing.T) {
+	i := interp.New(interp.Options{MaxGoroutines: 2, Stderr: io.Discard})
+	eval(t, i, `var c = make(chan int); func wait() { <-c }`)
+	eval(t, i, `go wait(); go func() { for {} }()`)
+
+	gs := i.Goroutines()
+	if len(gs) != 2 {
+		t.Fatalf("got %d goroutines, want 2", len(gs))
+	}
+	for j, g := range gs {
+		if pos := []string{"_.go:1:28", "_.go:1:39"}[j]; g.ID != uint64(j+1) || g.Pos.String() != pos {
+			t.Errorf("got goroutine %d at %v", g.ID, g.Pos)
+		}
+	}
+
+	_, err := i.Eval(`go wait()`)
+	if err == nil || err.Error() != "1:31 too many goroutines: limit of 2 reached" {
+		t.Errorf("got error %v, want too many goroutines", err)
+	}
+
+	// A goroutine ending makes room for another one.
+	eval(t, i, `c <- 1`)
+	for len(i.Goroutines()) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	eval(t, i, `go wait()`)
+
+	// Close stops the blocked and running goroutines.
+	done := make(chan error)
+	go func() { done <- i.Close() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for Close")
+	}
+	if gs := i.Goroutines(); len(gs) != 0 {
+		t.Errorf("got %d goroutines after Close, want 0", len(gs))
+	}
+}
+
+func TestGoroutinePanic(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		opt  interp.Options
+		src  string
+		err  string
+	}{
+		{
+			desc: "budget",
+			opt:  interp.Options{Budget: 1000},
+			src:  `go func() { for {} }()`,
+			err:  "1:44 execution budget of 1000 exceeded",
+		},
+		{
+			desc: "conversion",
+			opt:  interp.Options{ConversionFailure: interp.ConversionFailError},
+			src:  `go func() { var x interface{} = "abc"; n := 1; n = x; _ = n }()`,
+			err:  `1:75 failed to convert string to int: unable to cast "abc" of type string to int64`,
+		},
+		{
+			desc: "call depth",
+			opt:  interp.Options{MaxCallDepth: 100},
+			src:  `go func() { var f func(int) int; f = func(n int) int { return f(n + 1) }; f(0) }()`,
+			err:  "1:90 stack overflow: maximum call depth of 100 exceeded",
+		},
+		{
+			desc: "allocation",
+			opt:  interp.Options{MaxAlloc: 1 << 20},
+			src:  `go func() { _ = make([]byte, 1<<34) }()`,
+			err:  "1:44 allocation limit of 1048576 bytes exceeded",
+		},
+		{
+			desc: "panic",
+			src:  `go func() { panic("boom") }()`,
+			err:  "boom",
+		},
+	} {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			test.opt.Stderr = io.Discard
+			i := interp.New(test.opt)
+			eval(t, i, test.src)
+			for len(i.Goroutines()) != 0 {
+				time.Sleep(time.Millisecond)
+			}
+			if err := i.Close(); err == nil || err.Error() != test.err {
+				t.Errorf("got error %v, want %q", err, test.err)
+			}
+		})
+	}
+
+	// The panic is returned by the next execution.
+	i := interp.New(interp.Options{Budget: 1000, Stderr: io.Discard})
+	eval(t, i, `go func() { for {} }()`)
+	for len(i.Goroutines()) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := i.Eval(`1`); !errors.As(err, new(*interp.ErrBudgetExceeded)) {
+		t.Errorf("got error %v, want budget exceeded", err)
+	}
+	if res := eval(t, i, `2`); res.Interface() != 2 {
+		t.Errorf("got %v, want 2", res)
+	}
+}
+
+func TestMaxAlloc(t *testing.T) {
+	i := interp.New(interp.Options{MaxAlloc: 1 << 20, Stderr: io.Discard})
+
+	eval(t, i, `a := make([]int64, 1000)`)
+	if n := i.Allocated(); n != 8000 {
+		t.Errorf("got %d bytes allocated, want 8000", n)
+	}
+
+	for _, test := range []struct{ src, pos string }{
+		{src: `b := make([]byte, 1<<34)`, pos: "1:33"},
+		{src: `p := new([1 << 30]byte)`, pos: "1:33"},
+		{src: `s := []int{}; for j := 0; j < 1e6; j++ { s = append(s, j) }`, pos: "1:73"},
+		{src: `s := ""; for j := 0; j < 1e6; j++ { s += "0123456789" }`, pos: "1:64"},
+		{src: `m := []map[int]int{}; for j := 0; j < 1e6; j++ { m = append(m, map[int]int{j: j}) }`, pos: "1:81"},
+		{src: `var x interface{} = make([]byte, 1<<34)`, pos: "1:34"},
+	} {
+		_, err := i.Eval(test.src)
+		var e *interp.ErrAllocExceeded
+		if !errors.As(err, &e) {
+			t.Fatalf("%s: got error %v, want allocation limit exceeded", test.src, err)
+		}
+		if want := test.pos + " allocation limit of 1048576 bytes exceeded"; err.Error() != want {
+			t.Errorf("%s: got error %q, want %q", test.src, err, want)
+		}
+	}
+
+	// The count is reset by each execution.
+	if res := eval(t, i, `c := make([]byte, 1<<19); len(c)`); res.Interface() != 1<<19 {
+		t.Errorf("got %v, want %d", res, 1<<19)
+	}
+	eval(t, i, `d := make([]byte, 1<<19)`)
+
+	// An exceeded limit can not be recovered.
+	if _, err := i.Eval(`func() { defer func() { recover() }(); _ = make([]byte, 1<<34) }()`); !errors.As(err, new(*interp.ErrAllocExceeded)) {
+		t.Errorf("got error %v, want allocation limit exceeded", err)
+	}
+}
+
+func TestSymbolPolicy(t *testing.T) {
+	policy := &interp.SymbolPolicy{
+		Allow: []string{"net/http.Get"},
+		Deny:  []string{"os.Remove*", "net/http.*", "bytes.Buffer.Reset", "bytes.Buffer.String", "io.Writer.Write"},
+	}
+	for _, test := range []struct {
+		desc string
+		srcs []string
+		err  string
+	}{
+		{desc: "allowed", srcs: []string{`import "os"`, `os.Getpid()`}},
+		{desc: "denied", srcs: []string{`import "os"`, `os.RemoveAll("nothing")`}, err: "1:28 use of os.RemoveAll denied by symbol policy"},
+		{desc: "denied in func", srcs: []string{`import "os"`, `func f() { os.Remove("nothing") }`}, err: "1:25 use of os.Remove denied by symbol policy"},
+		{desc: "allow overrides deny", srcs: []string{`import "net/http"`, `_ = http.Get`}},
+		{desc: "denied type", srcs: []string{`import "net/http"`, `var c http.Client`}, err: "1:20 use of net/http.Client denied by symbol policy"},
+		{desc: "denied method", srcs: []string{`import "bytes"`, `var b bytes.Buffer`, `b.Reset()`}, err: "1:28 use of bytes.Buffer.Reset denied by symbol policy"},
+		{desc: "denied pointer method", srcs: []string{`import "bytes"`, `b := &bytes.Buffer{}`, `b.Reset()`}, err: "1:28 use of bytes.Buffer.Reset denied by symbol policy"},
+		{desc: "denied embedded method", srcs: []string{`import "bytes"`, `type T struct{ *bytes.Buffer }`, `t := T{&bytes.Buffer{}}`, `t.Reset()`}, err: "1:28 use of bytes.Buffer.Reset denied by symbol policy"},
+		{desc: "denied interface method", srcs: []string{`import ("io"; "os")`, `var w io.Writer = os.Stdout`, `w.Write(nil)`}, err: "1:28 use of io.Writer.Write denied by symbol policy"},
+		{desc: "denied dynamic method", srcs: []string{`import "bytes"`, `var r interface{ Reset() } = &bytes.Buffer{}`, `r.Reset()`}, err: "1:28 use of bytes.Buffer.Reset denied by symbol policy"},
+		{desc: "denied dynamic binary interface method", srcs: []string{`import ("bytes"; "fmt")`, `var s fmt.Stringer = &bytes.Buffer{}`, `s.String()`}, err: "1:28 use of bytes.Buffer.String denied by symbol policy"},
+		{desc: "allowed dynamic method", srcs: []string{`import ("bytes"; "io")`, `var r io.Reader = &bytes.Buffer{}`, `r.Read(nil)`}},
+		{desc: "dot import", srcs: []string{`import . "os"`, `Getpid()`}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			i := interp.New(interp.Options{SymbolPolicy: policy})
+			if err := i.Use(stdlib.Symbols); err != nil {
+				t.Fatal(err)
+			}
+			var err error
+			for _, src := range test.srcs {
+				if _, err = i.Eval(src); err != nil {
+					break
+				}
+			}
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("got error %v", err)
+			case test.err != "" && (err == nil || err.Error() != test.err):
+				t.Fatalf("got error %v, want %s", err, test.err)
+			}
+		})
+	}
+
+	// A policy given to UseWithPolicy restricts the packages it loads.
+	i := interp.New(interp.Options{})
+	if err := i.UseWithPolicy(stdlib.Symbols, &interp.SymbolPolicy{Allow: []string{"strings.*"}, Deny: []string{"*"}}); err != nil {
+		t.Fatal(err)
+	}
+	eval(t, i, `import ("os"; "strings")`)
+	if res := eval(t, i, `strings.ToUpper("ok")`); res.Interface() != "OK" {
+		t.Errorf("got %v, want OK", res)
+	}
+	if _, err := i.Eval(`os.Exit(1)`); err == nil || err.Error() != "1:28 use of os.Exit denied by symbol policy" {
+		t.Errorf("got error %v, want os.Exit denied", err)
+	}
+}
@@ -2,6 +2,7 @@ package interp_test
 
 import (
 	"bytes"
+	"flag"
 	"go/build"
 	"go/parser"
 	"go/token"
@@ -15,9 +16,46 @@ import (
 	"github.com/traefik/yaegi/stdlib/unsafe"
 )
 
+// strictOnly lists the test files whose expected errors only apply
+// to strict Go semantics, and not to the loose dialect.
+var strictOnly = map[string]bool{
+	"for7.go":       true, // non-bool condition
+	"fun28.go":      true, // return argument type mismatch
+	"fun29.go":      true, // return argument type mismatch
+	"if2.go":        true, // non-bool condition
+	"issue-1093.go": true, // string assigned to int
+	"op1.go":        true, // mismatched operand types
+	"op7.go":        true, // ordered comparison of interfaces
+}
+
+// testFlags is the command line flag set of the test.
+var testFlags = flag.CommandLine
+
+// superseded lists the test files whose expected errors predate the type
+// names printed in strict mode, with the files replacing them.
+var superseded = map[string]string{
+	"fun24.go": "fun28.go",
+	"fun25.go": "fun29.go",
+}
+
+// looseOnly lists the test files which rely on the loose dialect,
+// and are rejected by strict Go semantics.
+var looseOnly = map[string]bool{
+	"truthy0.go":     true, // non-bool conditions
+	"truthy1.go":     true, // non-bool conditions
+	"dynset0.go":     true, // dynamic selectors and indexes
+	"dynset1.go":     true, // dynamic selectors and indexes
+	"dynbltn0.go":    true, // builtins on dynamic values
+	"dynbltn1.go":    true, // range over dynamic values
+	"convstruct0.go": true, // implicit struct and map conversions
+	"convptr0.go":    true, // implicit pointer, func and array conversions
+	"convtime0.go":   true, // implicit time and duration conversions
+	"convsite0.go":   true, // implicit conversions at return, send, map key and literal sites
+}
+
 func TestFile(t *testing.T) {
 	filePath := "../_test/str.go"
-	runCheck(t, filePath)
+	runCheck(t, filePath, false)
 
 	defer func() {
 		_ = os.Setenv("YAEGI_SPECIAL_STDIO", "0")
@@ -29,18 +67,35 @@ func TestFile(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, file := range files {
-		if filepath.Ext(file.Name()) != ".go" {
-			continue
-		}
-		file := file
-		t.Run(file.Name(), func(t *testing.T) {
-			runCheck(t, filepath.Join(baseDir, file.Name()))
+	for _, mode := range []struct {
+		name   string
+		strict bool
+	}{{"loose", false}, {"strict", true}} {
+		mode := mode
+		t.Run(mode.name, func(t *testing.T) {
+			for _, file := range files {
+				if filepath.Ext(file.Name()) != ".go" {
+					continue
+				}
+				file := file
+				t.Run(file.Name(), func(t *testing.T) {
+					if s := superseded[file.Name()]; s != "" {
+						t.Skip(file.Name(), "is superseded by", s)
+					}
+					if !mode.strict && strictOnly[file.Name()] {
+						t.Skip(file.Name(), "expects strict Go semantics")
+					}
+					if mode.strict && looseOnly[file.Name()] {
+						t.Skip(file.Name(), "expects the loose dialect")
+					}
+					runCheck(t, filepath.Join(baseDir, file.Name()), mode.strict)
+				})
+			}
 		})
 	}
 }
 
-func runCheck(t *testing.T, p string) {
+func runCheck(t *testing.T, p string, strict bool) {
 	t.Helper()
 
 	wanted, goPath, errWanted := wantedFromComment(p)
@@ -52,8 +107,13 @@ func runCheck(t *testing.T, p string) {
 	if goPath == "" {
 		goPath = build.Default.GOPATH
 	}
+	// Each run defines its own command line flags, besides the test ones.
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	testFlags.VisitAll(func(f *flag.Flag) { flag.Var(f.Value, f.Name, f.Usage) })
+	defer func() { flag.CommandLine = testFlags }()
+
 	var stdout, stderr bytes.Buffer
-	i := interp.New(interp.Options{GoPath: goPath, Stdout: &stdout, Stderr: &stderr})
+	i := interp.New(interp.Options{GoPath: goPath, Stdout: &stdout, Stderr: &stderr, Strict: strict})
 	if err := i.Use(interp.Symbols); err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,215 @@
+package interp
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+)
+
+// A PackageInfo describes the declarations at package level of a source
+// package. The declarations of each kind are sorted by name.
+type PackageInfo struct {
+	Path   string // import path, or "main"
+	Name   string // package name
+	Consts []ValueInfo
+	Vars   []ValueInfo
+	Funcs  []FuncInfo
+	Types  []TypeInfo
+}
+
+// A ValueInfo describes a constant or a variable.
+type ValueInfo struct {
+	Name string
+	Type string
+	Pos  token.Position
+}
+
+// A FuncInfo describes a function or a method signature.
+type FuncInfo struct {
+	Name     string
+	Params   []string // parameter types, the last one of the form ...T if variadic
+	Results  []string // result types
+	Variadic bool
+	PtrRecv  bool // true for a method declared on a pointer receiver
+	Pos      token.Position
+}
+
+// A TypeInfo describes a defined type.
+type TypeInfo struct {
+	Name    string
+	Kind    string      // kind of the underlying type, as given by reflect.Kind
+	Fields  []FieldInfo // fields of a struct type, in declaration order
+	Methods []FuncInfo  // methods declared on the type, or of an interface type
+	Pos     token.Position
+}
+
+// A FieldInfo describes a struct field.
+type FieldInfo struct {
+	Name     string
+	Type     string
+	Tag      string
+	Embedded bool
+}
+
+// SourcePackages returns the import paths of the loaded source packages, sorted.
+func (interp *Interpreter) SourcePackages() []string {
+	interp.mutex.RLock()
+	defer interp.mutex.RUnlock()
+
+	paths := make([]string, 0, len(interp.srcPkg))
+	for path := range interp.srcPkg {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Package returns the description of the loaded source package of import
+// path, or "main" for the main package.
+func (interp *Interpreter) Package(path string) (*PackageInfo, error) {
+	interp.mutex.RLock()
+	defer interp.mutex.RUnlock()
+
+	syms, ok := interp.srcPkg[path]
+	if !ok {
+		return nil, fmt.Errorf("package %s not loaded", path)
+	}
+	info := &PackageInfo{Path: path, Name: interp.pkgNames[path]}
+	if info.Name == "" {
+		info.Name = path
+	}
+
+	for name, sym := range syms {
+		switch sym.kind {
+		case constSym:
+			info.Consts = append(info.Consts, ValueInfo{Name: name, Type: typeString(sym.typ), Pos: interp.symbolPos(name, sym)})
+		case varSym:
+			info.Vars = append(info.Vars, ValueInfo{Name: name, Type: typeString(sym.typ), Pos: interp.symbolPos(name, sym)})
+		case funcSym:
+			f := funcInfo(name, sym.typ)
+			f.Pos = interp.symbolPos(name, sym)
+			info.Funcs = append(info.Funcs, f)
+		case typeSym:
+			if sym.typ != nil {
+				info.Types = append(info.Types, interp.typeInfo(name, sym))
+			}
+		}
+	}
+
+	sort.Slice(info.Consts, func(i, j int) bool { return info.Consts[i].Name < info.Consts[j].Name })
+	sort.Slice(info.Vars, func(i, j int) bool { return info.Vars[i].Name < info.Vars[j].Name })
+	sort.Slice(info.Funcs, func(i, j int) bool { return info.Funcs[i].Name < info.Funcs[j].Name })
+	sort.Slice(info.Types, func(i, j int) bool { return info.Types[i].Name < info.Types[j].Name })
+	return info, nil
+}
+
+// typeInfo returns the description of the type symbol name.
+func (interp *Interpreter) typeInfo(name string, sym *symbol) TypeInfo {
+	info := TypeInfo{Name: name, Pos: interp.symbolPos(name, sym)}
+	t := sym.typ
+	u := t
+	for u.cat == aliasT && u.val != nil {
+		u = u.val
+	}
+
+	switch u.cat {
+	case structT:
+		info.Kind = reflect.Struct.String()
+		for _, f := range u.field {
+			info.Fields = append(info.Fields, FieldInfo{Name: f.name, Type: typeString(f.typ), Tag: f.tag, Embedded: f.embed})
+		}
+	case interfaceT:
+		info.Kind = reflect.Interface.String()
+		info.Methods = interfaceMethods(u, map[*itype]bool{})
+	default:
+		if !u.incomplete {
+			info.Kind = u.TypeOf().Kind().String()
+		}
+	}
+
+	for _, m := range t.method {
+		f := funcInfo(m.ident, m.typ)
+		f.PtrRecv = m.child[0].child[0].lastChild().kind == starExpr
+		f.Pos = interp.fset.Position(m.child[1].pos)
+		info.Methods = append(info.Methods, f)
+	}
+	sort.Slice(info.Methods, func(i, j int) bool { return info.Methods[i].Name < info.Methods[j].Name })
+	return info
+}
+
+// interfaceMethods returns the methods of the interface type t, including the
+// ones of its embedded interfaces.
+func interfaceMethods(t *itype, seen map[*itype]bool) (methods []FuncInfo) {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	for _, f := range t.field {
+		ft := f.typ
+		for ft.cat == aliasT && ft.val != nil {
+			ft = ft.val
+		}
+		switch ft.cat {
+		case funcT:
+			methods = append(methods, funcInfo(f.name, ft))
+		case interfaceT:
+			methods = append(methods, interfaceMethods(ft, seen)...)
+		case valueT, errorT:
+			rt := ft.TypeOf()
+			for i := 0; i < rt.NumMethod(); i++ {
+				m := rt.Method(i)
+				methods = append(methods, funcInfo(m.Name, valueTOf(m.Type)))
+			}
+		}
+	}
+	return methods
+}
+
+// funcInfo returns the description of the function name of type t, without
+// position.
+func funcInfo(name string, t *itype) FuncInfo {
+	f := FuncInfo{Name: name}
+	if t == nil {
+		return f
+	}
+	f.Variadic = t.isVariadic()
+	for i := 0; i < t.numIn(); i++ {
+		f.Params = append(f.Params, typeString(t.in(i)))
+	}
+	for i := 0; i < t.numOut(); i++ {
+		f.Results = append(f.Results, typeString(t.out(i)))
+	}
+	return f
+}
+
+// typeString returns the string representation of type t, as in source.
+func typeString(t *itype) string {
+	if t == nil {
+		return ""
+	}
+	if t.cat == valueT && t.rtype != nil && t.val == nil {
+		return t.rtype.String()
+	}
+	return t.id()
+}
+
+// symbolPos returns the position of the declaration of the symbol name.
+func (interp *Interpreter) symbolPos(name string, sym *symbol) token.Position {
+	n := sym.node
+	if n == nil && sym.typ != nil {
+		n = sym.typ.node
+	}
+	if n == nil {
+		return token.Position{}
+	}
+	pos, found := n.pos, false
+	n.Walk(func(c *node) bool {
+		if !found && c.kind == identExpr && c.ident == name {
+			pos, found = c.pos, true
+		}
+		return !found
+	}, nil)
+	return interp.fset.Position(pos)
+}
@@ -18,57 +18,58 @@ func add(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
-	switch typ.Kind() {
-	case reflect.Interface, reflect.String:
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
 		v0 := genValue(c0)
 		v1 := genValue(c1)
+		dest := genValue(n)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			if val0.Kind() == reflect.String || (val0.Kind() == reflect.Interface || val0.Kind() == reflect.Ptr) && val0.Elem().Kind() == reflect.String {
-				value = reflect.ValueOf(rconvToString(val0) + rconvToString(val1))
-			} else {
-				val0 = rconvNumber(val0)
-				val1 = rconvNumber(val1)
-				typ0 := val0.Type()
-				typ1 := val1.Type()
-				if isNumber(typ0) && isNumber(typ1) {
-					switch {
-					case isUint(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Uint() + val1.Uint())
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Uint() + uint64(val1.Int()))
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Uint() + uint64(val1.Float()))
-						}
-					case isInt(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Int() + int64(val1.Uint()))
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Int() + val1.Int())
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Int() + int64(val1.Float()))
-						}
-					case isFloat(typ0):
-						switch {
-						case isUint(typ1):
-							value = reflect.ValueOf(val0.Float() + float64(val1.Uint()))
-						case isInt(typ1):
-							value = reflect.ValueOf(val0.Float() + float64(val1.Int()))
-						case isFloat(typ1):
-							value = reflect.ValueOf(val0.Float() + float64(val1.Float()))
-						}
-					}
-				} else {
-					panic(n.runErrorf("only numbers support + operator"))
+			d := dest(f)
+			d.Set(n.rconv(addDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface, reflect.String:
+		if n.interp.strict && typ.Kind() == reflect.String {
+			switch {
+			case isInterface:
+				v0 := genValue(c0)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).Set(reflect.ValueOf(v0(f).String() + v1(f).String()).Convert(typ))
+					return next
+				}
+			case c0.rval.IsValid():
+				s0 := vString(c0.rval)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(s0 + v1(f).String())
+					return next
+				}
+			case c1.rval.IsValid():
+				v0 := genValue(c0)
+				s1 := vString(c1.rval)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(v0(f).String() + s1)
+					return next
+				}
+			default:
+				v0 := genValue(c0)
+				v1 := genValue(c1)
+				n.exec = func(f *frame) bltn {
+					dest(f).SetString(v0(f).String() + v1(f).String())
+					return next
 				}
 			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			break
+		}
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			dest(f).Set(n.rconv(addDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -226,9 +227,10 @@ func addConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := vConstantValue(v0)
-		cv1 := vConstantValue(v1)
-		cv1 = rconvConst(cv1, cv0.Kind())
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv1 = n.interp.conv.rconvConst(cv1, cv0.Kind())
+		}
 		v := constant.BinaryOp(cv0, token.ADD, cv1)
 		n.rval.Set(reflect.ValueOf(v))
 	case isString(t):
@@ -244,6 +246,26 @@ func addConst(n *node) {
 	}
 }
 
+// addDynamic applies the + operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func addDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.ADD, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.String:
+		r = reflect.ValueOf(o.x0.String() + o.x1.String())
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() + o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() + o.x1.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(o.x0.Float() + o.x1.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(o.x0.Complex() + o.x1.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func and(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -251,53 +273,25 @@ func and(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(andDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() & val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() & uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() & uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() & int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() & val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() & int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) & int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) & int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) & int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support & operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(andDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -387,8 +381,10 @@ func andConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.AND, constant.ToInt(cv1))
 		n.rval.Set(reflect.ValueOf(v))
 	case isUint(t):
@@ -398,6 +394,20 @@ func andConst(n *node) {
 	}
 }
 
+// andDynamic applies the & operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func andDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.AND, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() & o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() & o.x1.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 func andNot(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -405,53 +415,25 @@ func andNot(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(andNotDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() &^ val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() &^ uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() &^ uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() &^ int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() &^ val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() &^ int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) &^ int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) &^ int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) &^ int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support &^ operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(andNotDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -541,8 +523,10 @@ func andNotConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.AND_NOT, constant.ToInt(cv1))
 		n.rval.Set(reflect.ValueOf(v))
 	case isUint(t):
@@ -552,6 +536,20 @@ func andNotConst(n *node) {
 	}
 }
 
+// andNotDynamic applies the &^ operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func andNotDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.AND_NOT, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() &^ o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() &^ o.x1.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 func mul(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -559,53 +557,25 @@ func mul(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(mulDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() * val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() * uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() * uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() * int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() * val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() * int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Float() * float64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Float() * float64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Float() * float64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support * operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(mulDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -763,8 +733,10 @@ func mulConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstNumber(vConstantValue(v0))
-		cv1 := rconvConstNumber(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstNumber(cv0), n.interp.conv.rconvConstNumber(cv1)
+		}
 		v := constant.BinaryOp(cv0, token.MUL, cv1)
 		n.rval.Set(reflect.ValueOf(v))
 	case isComplex(t):
@@ -778,6 +750,24 @@ func mulConst(n *node) {
 	}
 }
 
+// mulDynamic applies the * operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func mulDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.MUL, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() * o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() * o.x1.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(o.x0.Float() * o.x1.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(o.x0.Complex() * o.x1.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func or(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -785,53 +775,25 @@ func or(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(orDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() | val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() | uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() | uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() | int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() | val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() | int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) | int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) | int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) | int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support | operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(orDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -921,8 +883,10 @@ func orConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.OR, constant.ToInt(cv1))
 		n.rval.Set(reflect.ValueOf(v))
 	case isUint(t):
@@ -932,6 +896,20 @@ func orConst(n *node) {
 	}
 }
 
+// orDynamic applies the | operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func orDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.OR, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() | o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() | o.x1.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 func quo(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -939,53 +917,25 @@ func quo(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(quoDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() / val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() / uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() / uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() / int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() / val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() / int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Float() / float64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Float() / float64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Float() / float64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support / operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(quoDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1152,8 +1102,10 @@ func quoConst(n *node) {
 		} else {
 			operator = token.QUO
 		}
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(cv0, operator, cv1)
 		n.rval.Set(reflect.ValueOf(v))
 	case isComplex(t):
@@ -1167,6 +1119,30 @@ func quoConst(n *node) {
 	}
 }
 
+// quoDynamic applies the / operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func quoDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.QUO, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		if o.x1.Int() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		r = reflect.ValueOf(o.x0.Int() / o.x1.Int())
+	case reflect.Uint64:
+		if o.x1.Uint() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		r = reflect.ValueOf(o.x0.Uint() / o.x1.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(o.x0.Float() / o.x1.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(o.x0.Complex() / o.x1.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func rem(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -1174,53 +1150,25 @@ func rem(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(remDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() % val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() % uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() % uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() % int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() % val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() % int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) % int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) % int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) % int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support % operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(remDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1310,8 +1258,10 @@ func remConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.REM, constant.ToInt(cv1))
 		n.rval.Set(reflect.ValueOf(v))
 	case isUint(t):
@@ -1321,6 +1271,26 @@ func remConst(n *node) {
 	}
 }
 
+// remDynamic applies the % operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func remDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.REM, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		if o.x1.Int() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		r = reflect.ValueOf(o.x0.Int() % o.x1.Int())
+	case reflect.Uint64:
+		if o.x1.Uint() == 0 {
+			panic(n.runErrorf("integer divide by zero"))
+		}
+		r = reflect.ValueOf(o.x0.Uint() % o.x1.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 func shl(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -1328,53 +1298,25 @@ func shl(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(shlDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() << val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() << uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() << uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() << int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() << val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() << int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) << int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) << int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) << int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support << operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(shlDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1473,6 +1415,26 @@ func shlConst(n *node) {
 	}
 }
 
+// shlDynamic applies the << operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func shlDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.SHL, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Int() << uint64(o.x1.Int()))
+	case reflect.Uint64:
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Uint() << uint64(o.x1.Int()))
+	}
+	return r.Convert(o.typ)
+}
+
 func shr(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -1480,53 +1442,25 @@ func shr(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(shrDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() >> val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() >> uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() >> uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() >> int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() >> val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() >> int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) >> int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) >> int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) >> int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support >> operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(shrDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1625,6 +1559,26 @@ func shrConst(n *node) {
 	}
 }
 
+// shrDynamic applies the >> operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func shrDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.SHR, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Int() >> uint64(o.x1.Int()))
+	case reflect.Uint64:
+		if o.x1.Int() < 0 {
+			panic(n.runErrorf("negative shift amount"))
+		}
+		r = reflect.ValueOf(o.x0.Uint() >> uint64(o.x1.Int()))
+	}
+	return r.Convert(o.typ)
+}
+
 func sub(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -1632,53 +1586,25 @@ func sub(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(subDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() - val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() - uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() - uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() - int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() - val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() - int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Float() - float64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Float() - float64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Float() - float64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support - operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(subDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1836,8 +1762,10 @@ func subConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstNumber(vConstantValue(v0))
-		cv1 := rconvConstNumber(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstNumber(cv0), n.interp.conv.rconvConstNumber(cv1)
+		}
 		v := constant.BinaryOp(cv0, token.SUB, cv1)
 		n.rval.Set(reflect.ValueOf(v))
 	case isComplex(t):
@@ -1851,6 +1779,24 @@ func subConst(n *node) {
 	}
 }
 
+// subDynamic applies the - operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func subDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.SUB, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() - o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() - o.x1.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(o.x0.Float() - o.x1.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(o.x0.Complex() - o.x1.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func xor(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.concrete().TypeOf()
@@ -1858,53 +1804,25 @@ func xor(n *node) {
 	dest := genValueOutput(n, typ)
 	c0, c1 := n.child[0], n.child[1]
 
+	if c0.typ.TypeOf().Kind() == reflect.Interface || c1.typ.TypeOf().Kind() == reflect.Interface {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		dest := genValue(n)
+		n.exec = func(f *frame) bltn {
+			d := dest(f)
+			d.Set(n.rconv(xorDynamic(n, v0(f), v1(f)), d.Type()))
+			return next
+		}
+		return
+	}
+
 	switch typ.Kind() {
 	case reflect.Interface, reflect.String:
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		n.exec = func(f *frame) bltn {
-			val0 := v0(f)
-			val1 := v1(f)
-			var value reflect.Value
-			val0 = rconvNumber(val0)
-			val1 = rconvNumber(val1)
-			typ0 := val0.Type()
-			typ1 := val1.Type()
-			if isNumber(typ0) && isNumber(typ1) {
-				switch {
-				case isUint(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Uint() ^ val1.Uint())
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Uint() ^ uint64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Uint() ^ uint64(val1.Float()))
-					}
-				case isInt(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(val0.Int() ^ int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(val0.Int() ^ val1.Int())
-					case isFloat(typ1):
-						value = reflect.ValueOf(val0.Int() ^ int64(val1.Float()))
-					}
-				case isFloat(typ0):
-					switch {
-					case isUint(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) ^ int64(val1.Uint()))
-					case isInt(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) ^ int64(val1.Int()))
-					case isFloat(typ1):
-						value = reflect.ValueOf(int64(val0.Float()) ^ int64(val1.Float()))
-					}
-				}
-			} else {
-				panic(n.runErrorf("only numbers support ^ operator"))
-			}
-			v, _ := rconv(value, typ)
-			dest(f).Set(v)
+			dest(f).Set(n.rconv(xorDynamic(n, v0(f), v1(f)), typ))
 			return next
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -1994,8 +1912,10 @@ func xorConst(n *node) {
 	n.rval = reflect.New(t).Elem()
 	switch {
 	case isConst:
-		cv0 := rconvConstInt(vConstantValue(v0))
-		cv1 := rconvConstInt(vConstantValue(v1))
+		cv0, cv1 := vConstantValue(v0), vConstantValue(v1)
+		if !n.interp.strict {
+			cv0, cv1 = n.interp.conv.rconvConstInt(cv0), n.interp.conv.rconvConstInt(cv1)
+		}
 		v := constant.BinaryOp(constant.ToInt(cv0), token.XOR, constant.ToInt(cv1))
 		n.rval.Set(reflect.ValueOf(v))
 	case isUint(t):
@@ -2005,12 +1925,30 @@ func xorConst(n *node) {
 	}
 }
 
+// xorDynamic applies the ^ operator to the dynamic operands v0 and v1,
+// according to the promotion rules of dynamic operators.
+func xorDynamic(n *node, v0, v1 reflect.Value) reflect.Value {
+	o := n.promote(token.XOR, v0, v1)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(o.x0.Int() ^ o.x1.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(o.x0.Uint() ^ o.x1.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 // Assign operators
 
 func addAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, add)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2019,6 +1957,20 @@ func addAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(addDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.String:
@@ -2145,6 +2097,10 @@ func andAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, and)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2153,6 +2109,20 @@ func andAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(andDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2212,6 +2182,10 @@ func andNotAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, andNot)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2220,6 +2194,20 @@ func andNotAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(andNotDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2279,6 +2267,10 @@ func mulAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, mul)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2287,6 +2279,20 @@ func mulAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(mulDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2391,6 +2397,10 @@ func orAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, or)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2399,6 +2409,20 @@ func orAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(orDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2458,6 +2482,10 @@ func quoAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, quo)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2466,6 +2494,20 @@ func quoAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(quoDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2570,6 +2612,10 @@ func remAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, rem)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2578,6 +2624,20 @@ func remAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(remDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2637,6 +2697,10 @@ func shlAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, shl)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2645,6 +2709,20 @@ func shlAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(shlDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2704,6 +2782,10 @@ func shrAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, shr)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2712,6 +2794,20 @@ func shrAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(shrDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2771,6 +2867,10 @@ func subAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, sub)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2779,6 +2879,20 @@ func subAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(subDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2883,6 +2997,10 @@ func xorAssign(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0, c1 := n.child[0], n.child[1]
+	if isDynamicIndex(c0) {
+		opAssignIndexGeneric(n, xor)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -2891,6 +3009,20 @@ func xorAssign(n *node) {
 		indexValue = genValue(c0.child[1])
 	}
 
+	if typ.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		n.exec = func(f *frame) bltn {
+			v := v0(f)
+			v.Set(xorDynamic(n, v, v1(f)))
+			if setMap {
+				mapValue(f).SetMapIndex(indexValue(f), v)
+			}
+			return next
+		}
+		return
+	}
+
 	if c1.rval.IsValid() {
 		switch typ.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -2950,6 +3082,10 @@ func dec(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0 := n.child[0]
+	if isDynamicIndex(c0) {
+		incDecIndexGeneric(n, -1)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -3006,6 +3142,10 @@ func inc(n *node) {
 	next := getExec(n.tnext)
 	typ := n.typ.TypeOf()
 	c0 := n.child[0]
+	if isDynamicIndex(c0) {
+		incDecIndexGeneric(n, +1)
+		return
+	}
 	setMap := isMapEntry(c0)
 	var mapValue, indexValue func(*frame) reflect.Value
 
@@ -3058,6 +3198,20 @@ func inc(n *node) {
 	}
 }
 
+// bitNotDynamic applies the unary ^ operator to the dynamic operand v,
+// according to the promotion rules of dynamic operators.
+func bitNotDynamic(n *node, v reflect.Value) reflect.Value {
+	o := n.promoteUnary(token.XOR, v)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(^o.x0.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(^o.x0.Uint())
+	}
+	return r.Convert(o.typ)
+}
+
 func bitNotConst(n *node) {
 	v0 := n.child[0].rval
 	isConst := v0.IsValid() && isConstantValue(v0.Type())
@@ -3077,6 +3231,24 @@ func bitNotConst(n *node) {
 	}
 }
 
+// negDynamic applies the unary - operator to the dynamic operand v,
+// according to the promotion rules of dynamic operators.
+func negDynamic(n *node, v reflect.Value) reflect.Value {
+	o := n.promoteUnary(token.SUB, v)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(-o.x0.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(-o.x0.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(-o.x0.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(-o.x0.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func negConst(n *node) {
 	v0 := n.child[0].rval
 	isConst := v0.IsValid() && isConstantValue(v0.Type())
@@ -3109,13 +3281,35 @@ func notConst(n *node) {
 	}
 	n.rval = reflect.New(t).Elem()
 	if isConst {
-		v := constant.UnaryOp(token.NOT, rconvConstBool(vConstantValue(v0)), 0)
+		cv0 := vConstantValue(v0)
+		if !n.interp.strict {
+			cv0 = n.interp.conv.rconvConstBool(cv0)
+		}
+		v := constant.UnaryOp(token.NOT, cv0, 0)
 		n.rval.Set(reflect.ValueOf(v))
 	} else {
-		n.rval.SetBool(!v0.Bool())
+		n.rval.SetBool(!n.interp.conv.truthy(v0))
 	}
 }
 
+// posDynamic applies the unary + operator to the dynamic operand v,
+// according to the promotion rules of dynamic operators.
+func posDynamic(n *node, v reflect.Value) reflect.Value {
+	o := n.promoteUnary(token.ADD, v)
+	var r reflect.Value
+	switch o.kind {
+	case reflect.Int64:
+		r = reflect.ValueOf(+o.x0.Int())
+	case reflect.Uint64:
+		r = reflect.ValueOf(+o.x0.Uint())
+	case reflect.Float64:
+		r = reflect.ValueOf(+o.x0.Float())
+	case reflect.Complex128:
+		r = reflect.ValueOf(+o.x0.Complex())
+	}
+	return r.Convert(o.typ)
+}
+
 func posConst(n *node) {
 	v0 := n.child[0].rval
 	isConst := v0.IsValid() && isConstantValue(v0.Type())
@@ -3139,6 +3333,29 @@ func posConst(n *node) {
 	}
 }
 
+// equalDynamic compares the dynamic operands v0 and v1 with the == operator,
+// according to the promotion rules of dynamic operators.
+func equalDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.EQL, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() == o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() == o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() == o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() == o.x1.Float()
+	case reflect.Complex128:
+		return o.x0.Complex() == o.x1.Complex()
+	case reflect.Bool:
+		return o.x0.Bool() == o.x1.Bool()
+	case reflect.Interface:
+		return o.x0.Interface() == o.x1.Interface()
+	}
+	panic(n.runErrorf("invalid operation: operator == not defined on %v", o.typ))
+}
+
 func equal(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -3147,7 +3364,8 @@ func equal(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
-	if c0.typ.cat == aliasT || c1.typ.cat == aliasT {
+	// In strict mode, interface operands are compared as is, without implicit conversion.
+	if c0.typ.cat == aliasT || c1.typ.cat == aliasT || n.interp.strict && (t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface) {
 		switch {
 		case isInterface:
 			v0 := genValue(c0)
@@ -3231,36 +3449,15 @@ func equal(n *node) {
 		return
 	}
 
-	// Do not attempt to optimize '==' or '!=' if an operand is an interface.
-	// This will preserve proper dynamic type checking at runtime. For static types,
-	// type checks are already performed, so bypass them if possible.
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
 	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				var err error
-				var typ0 reflect.Type
-				if val0.Kind() == reflect.Interface && val0.Elem().IsValid() {
-					typ0 = val0.Elem().Type()
-				} else {
-					typ0 = val0.Type()
-				}
-				val1, err = rconv(val1, typ0)
-				if err != nil {
-					panic(err)
-				}
-				i0 := val0.Interface()
-				i1 := val1.Interface()
-				x, err := compare(i0, i1, "==")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "=="))
-				}
-
-				if x {
+				if equalDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -3270,13 +3467,7 @@ func equal(n *node) {
 		} else {
 			dest := genValue(n)
 			n.exec = func(f *frame) bltn {
-				i0 := v0(f).Interface()
-				i1 := v1(f).Interface()
-				x, err := compare(i0, i1, "==")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "=="))
-				}
-				dest(f).Set(reflect.ValueOf(x))
+				dest(f).Set(reflect.ValueOf(equalDynamic(n, v0(f), v1(f))))
 				return tnext
 			}
 		}
@@ -3297,7 +3488,7 @@ func equal(n *node) {
 			}
 		case c0.rval.IsValid():
 			s0 := vString(c0.rval)
-			c0Bool := c0.rval.Kind() == reflect.Bool
+			c0Bool := !n.interp.strict && c0.rval.Kind() == reflect.Bool
 			v1 := genValueString(c1)
 			if n.fnext != nil {
 				fnext := getExec(n.fnext)
@@ -3762,6 +3953,23 @@ func equal(n *node) {
 	}
 }
 
+// greaterDynamic compares the dynamic operands v0 and v1 with the > operator,
+// according to the promotion rules of dynamic operators.
+func greaterDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.GTR, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() > o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() > o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() > o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() > o.x1.Float()
+	}
+	panic(n.runErrorf("invalid operation: operator > not defined on %v", o.typ))
+}
+
 func greater(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -3770,6 +3978,31 @@ func greater(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
+	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		if n.fnext != nil {
+			fnext := getExec(n.fnext)
+			n.exec = func(f *frame) bltn {
+				if greaterDynamic(n, v0(f), v1(f)) {
+					dest(f).SetBool(true)
+					return tnext
+				}
+				dest(f).SetBool(false)
+				return fnext
+			}
+		} else {
+			dest := genValue(n)
+			n.exec = func(f *frame) bltn {
+				dest(f).Set(reflect.ValueOf(greaterDynamic(n, v0(f), v1(f))))
+				return tnext
+			}
+		}
+		return
+	}
+
 	switch {
 	case isString(t0) || isString(t1):
 		switch {
@@ -4093,13 +4326,7 @@ func greater(n *node) {
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, ">")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				if v {
+				if greaterDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -4108,19 +4335,30 @@ func greater(n *node) {
 			}
 		} else {
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, ">")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				dest(f).SetBool(v)
+				dest(f).SetBool(greaterDynamic(n, v0(f), v1(f)))
 				return tnext
 			}
 		}
 	}
 }
 
+// greaterEqualDynamic compares the dynamic operands v0 and v1 with the >= operator,
+// according to the promotion rules of dynamic operators.
+func greaterEqualDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.GEQ, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() >= o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() >= o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() >= o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() >= o.x1.Float()
+	}
+	panic(n.runErrorf("invalid operation: operator >= not defined on %v", o.typ))
+}
+
 func greaterEqual(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -4129,6 +4367,31 @@ func greaterEqual(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
+	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		if n.fnext != nil {
+			fnext := getExec(n.fnext)
+			n.exec = func(f *frame) bltn {
+				if greaterEqualDynamic(n, v0(f), v1(f)) {
+					dest(f).SetBool(true)
+					return tnext
+				}
+				dest(f).SetBool(false)
+				return fnext
+			}
+		} else {
+			dest := genValue(n)
+			n.exec = func(f *frame) bltn {
+				dest(f).Set(reflect.ValueOf(greaterEqualDynamic(n, v0(f), v1(f))))
+				return tnext
+			}
+		}
+		return
+	}
+
 	switch {
 	case isString(t0) || isString(t1):
 		switch {
@@ -4452,13 +4715,7 @@ func greaterEqual(n *node) {
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, ">=")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				if v {
+				if greaterEqualDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -4467,19 +4724,30 @@ func greaterEqual(n *node) {
 			}
 		} else {
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, ">=")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				dest(f).SetBool(v)
+				dest(f).SetBool(greaterEqualDynamic(n, v0(f), v1(f)))
 				return tnext
 			}
 		}
 	}
 }
 
+// lowerDynamic compares the dynamic operands v0 and v1 with the < operator,
+// according to the promotion rules of dynamic operators.
+func lowerDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.LSS, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() < o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() < o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() < o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() < o.x1.Float()
+	}
+	panic(n.runErrorf("invalid operation: operator < not defined on %v", o.typ))
+}
+
 func lower(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -4488,6 +4756,31 @@ func lower(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
+	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		if n.fnext != nil {
+			fnext := getExec(n.fnext)
+			n.exec = func(f *frame) bltn {
+				if lowerDynamic(n, v0(f), v1(f)) {
+					dest(f).SetBool(true)
+					return tnext
+				}
+				dest(f).SetBool(false)
+				return fnext
+			}
+		} else {
+			dest := genValue(n)
+			n.exec = func(f *frame) bltn {
+				dest(f).Set(reflect.ValueOf(lowerDynamic(n, v0(f), v1(f))))
+				return tnext
+			}
+		}
+		return
+	}
+
 	switch {
 	case isString(t0) || isString(t1):
 		switch {
@@ -4811,13 +5104,7 @@ func lower(n *node) {
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, "<")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				if v {
+				if lowerDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -4826,19 +5113,30 @@ func lower(n *node) {
 			}
 		} else {
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, "<")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				dest(f).SetBool(v)
+				dest(f).SetBool(lowerDynamic(n, v0(f), v1(f)))
 				return tnext
 			}
 		}
 	}
 }
 
+// lowerEqualDynamic compares the dynamic operands v0 and v1 with the <= operator,
+// according to the promotion rules of dynamic operators.
+func lowerEqualDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.LEQ, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() <= o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() <= o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() <= o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() <= o.x1.Float()
+	}
+	panic(n.runErrorf("invalid operation: operator <= not defined on %v", o.typ))
+}
+
 func lowerEqual(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -4847,6 +5145,31 @@ func lowerEqual(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
+	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
+		v0 := genValue(c0)
+		v1 := genValue(c1)
+		if n.fnext != nil {
+			fnext := getExec(n.fnext)
+			n.exec = func(f *frame) bltn {
+				if lowerEqualDynamic(n, v0(f), v1(f)) {
+					dest(f).SetBool(true)
+					return tnext
+				}
+				dest(f).SetBool(false)
+				return fnext
+			}
+		} else {
+			dest := genValue(n)
+			n.exec = func(f *frame) bltn {
+				dest(f).Set(reflect.ValueOf(lowerEqualDynamic(n, v0(f), v1(f))))
+				return tnext
+			}
+		}
+		return
+	}
+
 	switch {
 	case isString(t0) || isString(t1):
 		switch {
@@ -5170,13 +5493,7 @@ func lowerEqual(n *node) {
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, "<=")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				if v {
+				if lowerEqualDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -5185,19 +5502,36 @@ func lowerEqual(n *node) {
 			}
 		} else {
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				v, e := rcompare(val0, val1, "<=")
-				if e != nil {
-					panic(n.runErrorf(e.Error()))
-				}
-				dest(f).SetBool(v)
+				dest(f).SetBool(lowerEqualDynamic(n, v0(f), v1(f)))
 				return tnext
 			}
 		}
 	}
 }
 
+// notEqualDynamic compares the dynamic operands v0 and v1 with the != operator,
+// according to the promotion rules of dynamic operators.
+func notEqualDynamic(n *node, v0, v1 reflect.Value) bool {
+	o := n.promote(token.NEQ, v0, v1)
+	switch o.kind {
+	case reflect.String:
+		return o.x0.String() != o.x1.String()
+	case reflect.Int64:
+		return o.x0.Int() != o.x1.Int()
+	case reflect.Uint64:
+		return o.x0.Uint() != o.x1.Uint()
+	case reflect.Float64:
+		return o.x0.Float() != o.x1.Float()
+	case reflect.Complex128:
+		return o.x0.Complex() != o.x1.Complex()
+	case reflect.Bool:
+		return o.x0.Bool() != o.x1.Bool()
+	case reflect.Interface:
+		return o.x0.Interface() != o.x1.Interface()
+	}
+	panic(n.runErrorf("invalid operation: operator != not defined on %v", o.typ))
+}
+
 func notEqual(n *node) {
 	tnext := getExec(n.tnext)
 	dest := genValueOutput(n, reflect.TypeOf(true))
@@ -5206,7 +5540,8 @@ func notEqual(n *node) {
 	c0, c1 := n.child[0], n.child[1]
 	t0, t1 := c0.typ.TypeOf(), c1.typ.TypeOf()
 
-	if c0.typ.cat == aliasT || c1.typ.cat == aliasT {
+	// In strict mode, interface operands are compared as is, without implicit conversion.
+	if c0.typ.cat == aliasT || c1.typ.cat == aliasT || n.interp.strict && (t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface) {
 		switch {
 		case isInterface:
 			v0 := genValue(c0)
@@ -5290,36 +5625,15 @@ func notEqual(n *node) {
 		return
 	}
 
-	// Do not attempt to optimize '==' or '!=' if an operand is an interface.
-	// This will preserve proper dynamic type checking at runtime. For static types,
-	// type checks are already performed, so bypass them if possible.
+	// In the loose dialect, interface operands are compared dynamically,
+	// following the promotion rules of dynamic operators.
 	if t0.Kind() == reflect.Interface || t1.Kind() == reflect.Interface {
 		v0 := genValue(c0)
 		v1 := genValue(c1)
 		if n.fnext != nil {
 			fnext := getExec(n.fnext)
 			n.exec = func(f *frame) bltn {
-				val0 := v0(f)
-				val1 := v1(f)
-				var err error
-				var typ0 reflect.Type
-				if val0.Kind() == reflect.Interface && val0.Elem().IsValid() {
-					typ0 = val0.Elem().Type()
-				} else {
-					typ0 = val0.Type()
-				}
-				val1, err = rconv(val1, typ0)
-				if err != nil {
-					panic(err)
-				}
-				i0 := val0.Interface()
-				i1 := val1.Interface()
-				x, err := compare(i0, i1, "!=")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "!="))
-				}
-
-				if x {
+				if notEqualDynamic(n, v0(f), v1(f)) {
 					dest(f).SetBool(true)
 					return tnext
 				}
@@ -5329,13 +5643,7 @@ func notEqual(n *node) {
 		} else {
 			dest := genValue(n)
 			n.exec = func(f *frame) bltn {
-				i0 := v0(f).Interface()
-				i1 := v1(f).Interface()
-				x, err := compare(i0, i1, "!=")
-				if err != nil {
-					panic(n.runErrorf("operator %s not supported here", "!="))
-				}
-				dest(f).Set(reflect.ValueOf(x))
+				dest(f).Set(reflect.ValueOf(notEqualDynamic(n, v0(f), v1(f))))
 				return tnext
 			}
 		}
@@ -5356,7 +5664,7 @@ func notEqual(n *node) {
 			}
 		case c0.rval.IsValid():
 			s0 := vString(c0.rval)
-			c0Bool := c0.rval.Kind() == reflect.Bool
+			c0Bool := !n.interp.strict && c0.rval.Kind() == reflect.Bool
 			v1 := genValueString(c1)
 			if n.fnext != nil {
 				fnext := getExec(n.fnext)
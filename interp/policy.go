@@ -0,0 +1,144 @@
+package interp
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// A SymbolPolicy allows or denies the use of individual symbols of the binary
+// packages by the interpreted code.
+//
+// The symbols are named by their import path and name, e.g. "net/http.Get",
+// and the methods by the import path and name of their type, followed by the
+// method name, e.g. "os.File.Close". In the patterns, '*' matches any
+// sequence of characters, so that "os.Remove*" matches os.Remove and
+// os.RemoveAll, and "net/http.*" matches all the symbols and methods of
+// net/http.
+//
+// A symbol is denied if it matches a Deny pattern and no Allow pattern. Deny
+// patterns of "*" make Allow an allowlist.
+//
+// The policy is enforced when the interpreted code is compiled: the use of a
+// denied symbol, or the selection of a denied method on a value of a binary
+// type, is a compile error. A method selected through an interface is checked
+// when the code runs, on the type of the dynamic value, and a denied one
+// panics with an error. The denied symbols are not imported by a dot import.
+type SymbolPolicy struct {
+	Allow []string // patterns of the allowed symbols
+	Deny  []string // patterns of the denied symbols
+}
+
+// allows returns true if the policy allows the symbol name.
+func (p *SymbolPolicy) allows(name string) bool {
+	if p == nil {
+		return true
+	}
+	for _, pattern := range p.Allow {
+		if matchSymbol(pattern, name) {
+			return true
+		}
+	}
+	for _, pattern := range p.Deny {
+		if matchSymbol(pattern, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSymbol returns true if name matches pattern, where '*' matches any
+// sequence of characters.
+func matchSymbol(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return len(name) >= len(last) && strings.HasSuffix(name, last)
+}
+
+// UseWithPolicy is like Use, but restricts the use of the loaded symbols to
+// the ones allowed by policy, in addition to Options.SymbolPolicy. The policy
+// replaces the one given for the same packages by a previous call.
+func (interp *Interpreter) UseWithPolicy(values Exports, policy *SymbolPolicy) error {
+	if err := interp.Use(values); err != nil {
+		return err
+	}
+	interp.mutex.Lock()
+	defer interp.mutex.Unlock()
+	if interp.policies == nil {
+		interp.policies = map[string]*SymbolPolicy{}
+	}
+	for k := range values {
+		interp.policies[path.Dir(k)] = policy
+	}
+	return nil
+}
+
+// allowsSymbol returns true if the symbol name of the binary package of
+// import path ipath may be used.
+func (interp *Interpreter) allowsSymbol(ipath, name string) bool {
+	interp.mutex.RLock()
+	p := interp.policies[ipath]
+	interp.mutex.RUnlock()
+	if p == nil && interp.symbolPolicy == nil {
+		return true
+	}
+	sym := ipath + "." + name
+	return interp.symbolPolicy.allows(sym) && p.allows(sym)
+}
+
+// checkSymbol returns an error if the symbol name of the binary package of
+// import path ipath, selected by node n, is denied.
+func (interp *Interpreter) checkSymbol(n *node, ipath, name string) error {
+	if interp.allowsSymbol(ipath, name) {
+		return nil
+	}
+	return n.cfgErrorf("use of %s.%s denied by symbol policy", ipath, name)
+}
+
+// checkBinMethod returns an error if the method selected by node n on a value
+// of the binary type t is denied.
+func (interp *Interpreter) checkBinMethod(n *node, t reflect.Type) error {
+	t = namedBinType(t)
+	if t == nil {
+		return nil
+	}
+	return interp.checkSymbol(n, t.PkgPath(), t.Name()+"."+n.child[1].ident)
+}
+
+// checkDynMethod panics if the method selected by node n through an
+// interface, on a dynamic value of type t, is denied.
+func (n *node) checkDynMethod(t reflect.Type) {
+	t = namedBinType(t)
+	if t == nil {
+		return
+	}
+	if name := t.Name() + "." + n.child[1].ident; !n.interp.allowsSymbol(t.PkgPath(), name) {
+		panic(n.runErrorf("use of %s.%s denied by symbol policy", t.PkgPath(), name))
+	}
+}
+
+// namedBinType returns the named type of t, or of the pointer type t, or nil
+// if t is not named by a binary package.
+func namedBinType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return nil
+	}
+	return t
+}
@@ -133,14 +133,33 @@ func (interp *Interpreter) CompileAST(n ast.Node) (*Program, error) {
 
 // Execute executes compiled Go code.
 func (interp *Interpreter) Execute(p *Program) (res reflect.Value, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			var pc [64]uintptr // 64 frames should be enough.
-			n := runtime.Callers(1, pc[:])
-			err = Panic{Value: r, Callers: pc[:n], Stack: debug.Stack()}
-		}
-	}()
+	interp.arm(interp.budget)
+	return interp.execute(p, interp.budget)
+}
+
+// recoverPanic sets *err to the error of a panic of the interpreted code.
+// It must be deferred.
+func (interp *Interpreter) recoverPanic(err *error) {
+	r := recover()
+	if interp.unrecoverable(r) {
+		*err = r.(error)
+		return
+	}
+	if r != nil {
+		var pc [64]uintptr // 64 frames should be enough.
+		n := runtime.Callers(1, pc[:])
+		*err = Panic{Value: r, Callers: pc[:n], Stack: debug.Stack()}
+	}
+}
+
+// execute executes program p on a new execution frame with the given budget.
+func (interp *Interpreter) execute(p *Program, budget int64) (res reflect.Value, err error) {
+	defer interp.recoverPanic(&err)
+
+	// Report the panic of a goroutine started by a previous execution.
+	if err = interp.goroutineErr(); err != nil {
+		return res, err
+	}
 
 	// Generate node exec closures.
 	if err = genRun(p.root); err != nil {
@@ -152,27 +171,28 @@ func (interp *Interpreter) Execute(p *Program) (res reflect.Value, err error) {
 	interp.frame.mutex.Lock()
 	interp.resizeFrame()
 	interp.frame.mutex.Unlock()
+	f := interp.execFrame(budget)
 
 	// Execute node closures.
-	interp.run(p.root, nil)
+	interp.runFrame(p.root, f)
 
 	// Wire and execute global vars.
 	n, err := genGlobalVars([]*node{p.root}, interp.scopes[p.pkgName])
 	if err != nil {
 		return res, err
 	}
-	interp.run(n, nil)
+	interp.runFrame(n, f)
 
 	for _, n := range p.init {
-		interp.run(n, interp.frame)
+		interp.run(n, f)
 	}
 	v := genValue(p.root)
-	res = v(interp.frame)
+	res = v(f)
 
 	// If result is an interpreter node, wrap it in a runtime callable function.
 	if res.IsValid() {
 		if n, ok := res.Interface().(*node); ok {
-			res = genFunctionWrapper(n)(interp.frame)
+			res = genFunctionWrapper(n)(f)
 		}
 	}
 
@@ -181,15 +201,19 @@ func (interp *Interpreter) Execute(p *Program) (res reflect.Value, err error) {
 
 // ExecuteWithContext executes compiled Go code.
 func (interp *Interpreter) ExecuteWithContext(ctx context.Context, p *Program) (res reflect.Value, err error) {
+	interp.arm(interp.budgetOf(ctx))
+	return interp.executeWithContext(ctx, p)
+}
+
+func (interp *Interpreter) executeWithContext(ctx context.Context, p *Program) (res reflect.Value, err error) {
 	interp.mutex.Lock()
-	interp.done = make(chan struct{})
-	interp.cancelChan = !interp.opt.fastChan
+	interp.renewDone()
 	interp.mutex.Unlock()
 
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		res, err = interp.Execute(p)
+		res, err = interp.execute(p, interp.budgetOf(ctx))
 	}()
 
 	select {
@@ -7,10 +7,10 @@ import (
 	"fmt"
 	"github.com/spf13/cast"
 	"go/constant"
-	"go/token"
 	"reflect"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // bltn type defines functions which run at CFG execution.
@@ -107,6 +107,14 @@ func (interp *Interpreter) run(n *node, cf *frame) {
 	} else {
 		f = newFrame(cf, len(n.types), interp.runid())
 	}
+	interp.runFrame(n, f)
+}
+
+// runFrame executes node n on frame f.
+func (interp *Interpreter) runFrame(n *node, f *frame) {
+	if n == nil {
+		return
+	}
 	interp.mutex.RLock()
 	c := reflect.ValueOf(interp.done)
 	interp.mutex.RUnlock()
@@ -185,6 +193,9 @@ func runCfg(n *node, f *frame, funcNode, callNode *node) {
 			val[0].Call(val[1:])
 		}
 		if f.recovered != nil {
+			if e, ok := f.recovered.(*CallDepthError); ok && callNode != nil {
+				e.addCall(callNode)
+			}
 			oNode := originalExecNode(n, exec)
 			if oNode == nil {
 				oNode = n
@@ -282,6 +293,33 @@ func typeAssert(n *node, withResult, withOk bool) {
 	rtype := typ.rtype // type to assert
 	next := getExec(n.tnext)
 
+	if n.interp.coerceAssert && !n.interp.strict && isCoercible(typ.TypeOf()) {
+		n.exec = func(f *frame) bltn {
+			v := concreteValue(value(f))
+			ok := true
+			var r reflect.Value
+			switch {
+			case v.IsValid() && canAssertTypes(v.Type(), typ.TypeOf()):
+				r = v
+			case withOk:
+				r, ok = n.interp.conv.coerceLossless(v, typ.TypeOf())
+			default:
+				var err error
+				if r, err = n.interp.conv.coerce(v, typ.TypeOf()); err != nil {
+					r = n.convFailed(v, typ.TypeOf(), err)
+				}
+			}
+			if withResult {
+				value0(f).Set(r)
+			}
+			if setStatus {
+				value1(f).SetBool(ok)
+			}
+			return next
+		}
+		return
+	}
+
 	switch {
 	case isInterfaceSrc(typ):
 		n.exec = func(f *frame) bltn {
@@ -595,15 +633,7 @@ func convert(n *node) {
 		value = genValue(c)
 	}
 
-	for _, con := range n.interp.hooks.convert {
-		if c.typ.rtype == nil {
-			continue
-		}
-
-		fn := con(c.typ.rtype, typ)
-		if fn == nil {
-			continue
-		}
+	if fn := n.interp.conv.lookup(c.typ.rtype, typ); fn != nil {
 		n.exec = func(f *frame) bltn {
 			fn(value(f), dest(f))
 			return next
@@ -654,6 +684,7 @@ func assign(n *node) {
 	dvalue := make([]func(*frame) reflect.Value, n.nleft)
 	ivalue := make([]func(*frame) reflect.Value, n.nleft)
 	svalue := make([]func(*frame) reflect.Value, n.nleft)
+	setter := make([]func(*frame, reflect.Value), n.nleft)
 	var sbase int
 	if n.nright > 0 {
 		sbase = len(n.child) - n.nright
@@ -674,9 +705,11 @@ func assign(n *node) {
 					return reflect.ValueOf(dest.child[1].ident)
 				}
 			} else {
-				ivalue[i] = genValue(dest.child[1])
+				ivalue[i] = genValueMapKey(dest.child[0], dest.child[1])
 			}
 			dvalue[i] = genValue(dest.child[0])
+		} else if isDynamicIndex(dest) {
+			setter[i] = genSetIndexGeneric(dest)
 		} else {
 			dvalue[i] = genValue(dest)
 		}
@@ -684,11 +717,16 @@ func assign(n *node) {
 
 	if n.nleft == 1 {
 		// Single assign operation.
-		switch s, d, i := svalue[0], dvalue[0], ivalue[0]; {
+		switch s, d, i, set := svalue[0], dvalue[0], ivalue[0], setter[0]; {
 		case n.child[0].ident == "_":
 			n.exec = func(f *frame) bltn {
 				return next
 			}
+		case set != nil:
+			n.exec = func(f *frame) bltn {
+				set(f, s(f))
+				return next
+			}
 		case i != nil:
 			n.exec = func(f *frame) bltn {
 				dest := d(f)
@@ -701,46 +739,24 @@ func assign(n *node) {
 		case n.kind == defineStmt:
 			l := n.level
 			ind := n.findex
+			conv := needsImplicitConv(n.child[sbase], n.child[0].typ)
 			n.exec = func(f *frame) bltn {
 				data := getFrame(f, l).data
 				data[ind] = reflect.New(data[ind].Type()).Elem()
 				sval := s(f)
-				if sval.IsValid() {
-					data[ind].Set(sval)
-				} else {
+				switch {
+				case !sval.IsValid():
 					panic(n.child[1].runErrorf("invalid value"))
+				case conv:
+					n.rconvAndSet(data[ind], sval)
+				default:
+					data[ind].Set(sval)
 				}
 				return next
 			}
 		default:
 			n.exec = func(f *frame) bltn {
-				vleft := d(f)
-				vright := s(f)
-				err := rconvAndSet(vleft, vright)
-				if err != nil {
-					panic(n.runErrorf("failed to convert %s to %s", vright.Type(), vleft.Type()))
-				}
-				if n.child[0].kind == selectorExpr || n.child[0].kind == indexExpr {
-					left := n.child[0].child[0]
-					right := n.child[0].child[1]
-					lval := rconvToConcrete(valueGenerator(left, left.findex)(f))
-					if lval.Kind() == reflect.Map {
-						var key reflect.Value
-						switch right.kind {
-						case basicLit:
-							k := constant.StringVal(constant.MakeFromLiteral(right.ident, token.STRING, 0))
-							key = reflect.ValueOf(k)
-						case identExpr:
-							switch n.child[0].kind {
-							case selectorExpr:
-								key = reflect.ValueOf(right.ident)
-							case indexExpr:
-								key = genValue(right)(f)
-							}
-						}
-						lval.SetMapIndex(key, vright)
-					}
-				}
+				n.rconvAndSet(d(f), s(f))
 				return next
 			}
 		}
@@ -800,7 +816,9 @@ func assign(n *node) {
 			if n.child[i].ident == "_" {
 				continue
 			}
-			if j := ivalue[i]; j != nil {
+			if set := setter[i]; set != nil {
+				set(f, t[i]) // Assign through a dynamic selector or index
+			} else if j := ivalue[i]; j != nil {
 				d(f).SetMapIndex(j(f), t[i]) // Assign a map entry
 			} else {
 				d(f).Set(t[i]) // Assign a var or array/slice entry
@@ -812,20 +830,15 @@ func assign(n *node) {
 
 func not(n *node) {
 	dest := genValue(n)
-	value := genValue(n.child[0])
+	truthy := genValueTruthy(n.child[0], genValue(n.child[0]))
 	tnext := getExec(n.tnext)
 
 	if n.fnext != nil {
 		fnext := getExec(n.fnext)
 		n.exec = func(f *frame) bltn {
-			val := cast.ToBool(value(f).Interface())
+			val := truthy(f)
 			dval := dest(f)
-			dtype := dval.Type()
-			rval := reflect.ValueOf(!val)
-			v, e := rconv(rval, dtype)
-			if e != nil {
-				panic(n.runErrorf("failed to convert %s to %s", rval.Type(), dtype))
-			}
+			v := n.rconv(reflect.ValueOf(!val), dval.Type())
 			if !val {
 				dval.Set(v)
 				return tnext
@@ -835,15 +848,8 @@ func not(n *node) {
 		}
 	} else {
 		n.exec = func(f *frame) bltn {
-			val := !cast.ToBool(value(f).Interface())
 			dval := dest(f)
-			rval := reflect.ValueOf(val)
-			dtype := dval.Type()
-			v, e := rconv(rval, dtype)
-			if e != nil {
-				panic(n.runErrorf("failed to convert %s to %s", rval.Type(), dtype))
-			}
-			dval.Set(v)
+			dval.Set(n.rconv(reflect.ValueOf(!truthy(f)), dval.Type()))
 			return tnext
 		}
 	}
@@ -939,7 +945,7 @@ func _recover(n *node) {
 	dest := genValue(n)
 
 	n.exec = func(f *frame) bltn {
-		if f.anc.recovered == nil {
+		if f.anc.recovered == nil || n.interp.unrecoverable(f.anc.recovered) {
 			// TODO(mpl): maybe we don't need that special case, and we're just forgetting to unwrap the valueInterface somewhere else.
 			if isEmptyInterface(n.typ) {
 				return tnext
@@ -1015,8 +1021,7 @@ func genFunctionWrapper(n *node) func(*frame) reflect.Value {
 	if def, ok = n.val.(*node); !ok {
 		return genValueAsFunctionWrapper(n)
 	}
-	start := def.child[3].start
-	numRet := len(def.typ.ret)
+	call := genFunctionCall(n, def)
 	var rcvr func(*frame) reflect.Value
 
 	if n.recv != nil {
@@ -1033,63 +1038,88 @@ func genFunctionWrapper(n *node) func(*frame) reflect.Value {
 			f = n.frame
 		}
 		return reflect.MakeFunc(funcType, func(in []reflect.Value) []reflect.Value {
-			// Allocate and init local frame. All values to be settable and addressable.
-			fr := newFrame(f, len(def.types), f.runid())
-			d := fr.data
-			for i, t := range def.types {
-				d[i] = reflect.New(t).Elem()
+			var recv reflect.Value
+			if rcvr != nil {
+				recv = rcvr(f)
 			}
+			return call(f, recv, in)
+		})
+	}
+}
 
-			if rcvr == nil {
-				d = d[numRet:]
-			} else {
-				// Copy method receiver as first argument.
-				src, dest := rcvr(f), d[numRet]
-				sk, dk := src.Kind(), dest.Kind()
-				switch {
-				case sk == reflect.Ptr && dk != reflect.Ptr:
-					dest.Set(src.Elem())
-				case sk != reflect.Ptr && dk == reflect.Ptr:
-					dest.Set(src.Addr())
-				default:
-					if wrappedSrc, ok := src.Interface().(valueInterface); ok {
-						src = wrappedSrc.value
-					}
-					dest.Set(src)
-				}
-				d = d[numRet+1:]
-			}
+// genFunctionCall returns a function calling from frame f the interpreted
+// function def, referred to by node n, with the method receiver recv if valid,
+// and the input arguments in, and returning its results.
+func genFunctionCall(n, def *node) func(f *frame, recv reflect.Value, in []reflect.Value) []reflect.Value {
+	start := def.child[3].start
+	numRet := len(def.typ.ret)
 
-			// Copy function input arguments in local frame.
-			for i, arg := range in {
-				if i >= len(d) {
-					// In case of unused arg, there may be not even a frame entry allocated, just skip.
-					break
-				}
-				typ := def.typ.arg[i]
-				switch {
-				case isEmptyInterface(typ):
-					d[i].Set(arg)
-				case isInterfaceSrc(typ):
-					d[i].Set(reflect.ValueOf(valueInterface{value: arg.Elem()}))
-				case isFuncSrc(typ) && arg.Kind() == reflect.Func:
-					d[i].Set(reflect.ValueOf(genFunctionNode(arg)))
-				default:
-					d[i].Set(arg)
-				}
+	return func(f *frame, recv reflect.Value, in []reflect.Value) []reflect.Value {
+		// Allocate and init local frame. All values to be settable and addressable.
+		fr := newFrame(f, len(def.types), f.runid())
+		fr.depth = f.depth + 1
+		if max := n.interp.maxCallDepth; max > 0 && fr.depth > max {
+			panic(n.callDepthError(max))
+		}
+		d := fr.data
+		for i, t := range def.types {
+			d[i] = reflect.New(t).Elem()
+		}
+
+		if !recv.IsValid() {
+			d = d[numRet:]
+		} else {
+			// Copy method receiver as first argument.
+			setRecv(d[numRet], recv)
+			d = d[numRet+1:]
+		}
+
+		// Copy function input arguments in local frame.
+		for i, arg := range in {
+			if i >= len(d) {
+				// In case of unused arg, there may be not even a frame entry allocated, just skip.
+				break
+			}
+			typ := def.typ.arg[i]
+			switch {
+			case isEmptyInterface(typ):
+				d[i].Set(arg)
+			case isInterfaceSrc(typ):
+				d[i].Set(reflect.ValueOf(valueInterface{value: arg.Elem()}))
+			case isFuncSrc(typ) && arg.Kind() == reflect.Func:
+				d[i].Set(reflect.ValueOf(genFunctionNode(arg)))
+			default:
+				d[i].Set(arg)
 			}
+		}
 
-			// Interpreter code execution.
-			runCfg(start, fr, def, n)
+		// Interpreter code execution.
+		runCfg(start, fr, def, n)
 
-			result := fr.data[:numRet]
-			for i, r := range result {
-				if v, ok := r.Interface().(*node); ok {
-					result[i] = genFunctionWrapper(v)(f)
-				}
+		result := fr.data[:numRet]
+		for i, r := range result {
+			if v, ok := r.Interface().(*node); ok {
+				result[i] = genFunctionWrapper(v)(f)
 			}
-			return result
-		})
+		}
+		return result
+	}
+}
+
+// setRecv sets the receiver dest of a method call to src, dereferencing or
+// taking the address of src as required by the method.
+func setRecv(dest, src reflect.Value) {
+	sk, dk := src.Kind(), dest.Kind()
+	switch {
+	case sk == reflect.Ptr && dk != reflect.Ptr:
+		dest.Set(src.Elem())
+	case sk != reflect.Ptr && dk == reflect.Ptr:
+		dest.Set(src.Addr())
+	default:
+		if wrappedSrc, ok := src.Interface().(valueInterface); ok {
+			src = wrappedSrc.value
+		}
+		dest.Set(src)
 	}
 }
 
@@ -1372,7 +1402,7 @@ func call(n *node) {
 				in[i] = v(f)
 			}
 			if goroutine {
-				go bf.Call(in)
+				n.interp.spawn(n, func() { bf.Call(in) })
 				return tnext
 			}
 			out := bf.Call(in)
@@ -1393,6 +1423,13 @@ func call(n *node) {
 			anc = def.frame
 		}
 		nf := newFrame(anc, len(def.types), anc.runid())
+		nf.meter, nf.allocs = f.meter, f.allocs
+		if !goroutine {
+			nf.depth = f.depth + 1
+			if max := n.interp.maxCallDepth; max > 0 && nf.depth > max {
+				panic(n.callDepthError(max))
+			}
+		}
 		var vararg reflect.Value
 
 		// Init return values
@@ -1488,7 +1525,7 @@ func call(n *node) {
 
 		// Execute function body
 		if goroutine {
-			go runCfg(def.child[3].start, nf, def, n)
+			n.interp.spawn(n, func() { runCfg(def.child[3].start, nf, def, n) })
 			return tnext
 		}
 		runCfg(def.child[3].start, nf, def, n)
@@ -1541,7 +1578,12 @@ func callBin(n *node) {
 	}
 
 	// Determine if we should use `Call` or `CallSlice` on the function Value.
+	// Outside of strict mode, arguments are implicitly converted to the expected types.
+	strict := n.interp.strict
 	callFn := func(v reflect.Value, in []reflect.Value) []reflect.Value {
+		if strict {
+			return v.Call(in)
+		}
 		vType := v.Type()
 		vNumIn := vType.NumIn()
 		for i := 0; i < vNumIn; i++ {
@@ -1558,11 +1600,7 @@ func callBin(n *node) {
 					// variadic argument, no cast
 					break
 				}
-				casted, err := rconv(inVal, inTypeExpected)
-				if err != nil {
-					panic(n.runErrorf("failed to convert %s to %s", inType, inTypeExpected))
-				}
-				in[i] = casted
+				in[i] = n.rconv(inVal, inTypeExpected)
 			}
 		}
 		return v.Call(in)
@@ -1663,7 +1701,8 @@ func callBin(n *node) {
 			for i, v := range values {
 				in[i] = v(f)
 			}
-			go callFn(value(f), in)
+			fn := value(f)
+			n.interp.spawn(n, func() { callFn(fn, in) })
 			return tnext
 		}
 	case fnext != nil:
@@ -1712,10 +1751,7 @@ func callBin(n *node) {
 					if v != nil {
 						rr := v(f)
 						ro := out[i]
-						err := rconvAndSet(rr, ro)
-						if err != nil {
-							panic(n.runErrorf("failed to convert %s to %s", ro.Type(), rr.Type()))
-						}
+						n.rconvAndSet(rr, ro)
 					}
 				}
 				return tnext
@@ -1772,6 +1808,19 @@ func getIndexBinMethod(n *node) {
 	value := genValue(n.child[0])
 	next := getExec(n.tnext)
 
+	if t := n.child[0].typ.TypeOf(); t != nil && t.Kind() == reflect.Interface {
+		// The method of the dynamic value is checked against the symbol policy.
+		n.exec = func(f *frame) bltn {
+			v := value(f)
+			if e := v.Elem(); e.IsValid() {
+				n.checkDynMethod(e.Type())
+			}
+			getFrame(f, l).data[i] = v.Method(m)
+			return next
+		}
+		return
+	}
+
 	n.exec = func(f *frame) bltn {
 		// Can not use .Set() because dest type contains the receiver and source not
 		// dest(f).Set(value(f).Method(m))
@@ -1857,46 +1906,214 @@ func getIndexArray(n *node) {
 	}
 }
 
+// getIndexGeneric gets the element designated by the dynamic selector or
+// index expression n: a map entry, a slice, array or string element, or a
+// struct field. An index out of range, or an unexported field of a binary
+// struct, is a runtime error, and a missing map entry or struct field, or the
+// element of a nil value, leaves the destination unset, unless the
+// interpreter is nil-safe, in which case they all yield the zero value.
 func getIndexGeneric(n *node) {
 	dest := genValue(n)
 	tnext := getExec(n.tnext)
 	value0 := genValue(n.child[0])
-	var value1 func(*frame) reflect.Value
-	if n.child[1].kind == identExpr && n.kind == selectorExpr {
-		name := n.child[1].ident
-		value1 = func(f *frame) reflect.Value {
-			return reflect.ValueOf(name)
+	value1 := genIndexKey(n)
+	n.exec = func(f *frame) bltn {
+		v, err := n.indexGeneric(concreteValue(value0(f)), value1(f))
+		nvalue := dest(f)
+		switch {
+		case v.IsValid():
+			nvalue.Set(n.rconv(v, nvalue.Type()))
+		case n.interp.nilSafe:
+			nvalue.Set(reflect.Zero(nvalue.Type()))
+		case err != nil:
+			panic(n.runErrorf("%v", err))
 		}
-	} else {
-		value1 = genValue(n.child[1])
+		return tnext
 	}
-	n.exec = func(f *frame) bltn {
-		value := value0(f)
-		if value.Kind() == reflect.Interface {
-			value = value.Elem()
+
+	if n.fnext != nil {
+		// The expression is a condition, branch on its truthiness.
+		fnext := getExec(n.fnext)
+		exec := n.exec
+		truthy := genValueTruthy(n, dest)
+		n.exec = func(f *frame) bltn {
+			exec(f)
+			if truthy(f) {
+				return tnext
+			}
+			return fnext
+		}
+	}
+}
+
+// indexGeneric returns the element of the dynamic value designated by key,
+// or an invalid value if the element is missing. An error is returned if the
+// element can not be accessed.
+func (n *node) indexGeneric(value, key reflect.Value) (reflect.Value, error) {
+	switch value.Kind() {
+	case reflect.Map:
+		if !n.interp.nilSafe {
+			return value.MapIndex(n.rconv(key, value.Type().Key())), nil
+		}
+		// A key which does not convert to the key type is a missing key.
+		k, err := n.interp.conv.rconv(key, value.Type().Key())
+		if err != nil || !k.IsValid() {
+			return reflect.Value{}, nil
 		}
+		return value.MapIndex(k), nil
+	case reflect.Slice, reflect.Array, reflect.String:
+		i, err := cast.ToIntE(concreteValue(key).Interface())
+		if err != nil {
+			return reflect.Value{}, nil
+		}
+		if i < 0 || i >= value.Len() {
+			return reflect.Value{}, fmt.Errorf("index out of range [%d] with length %d", i, value.Len())
+		}
+		return value.Index(i), nil
+	case reflect.Ptr, reflect.Struct:
+		v := fieldByName(value, key)
+		if v.IsValid() && !v.CanInterface() {
+			return reflect.Value{}, fmt.Errorf("cannot access unexported field %v of %v", key, value.Type())
+		}
+		return v, nil
+	}
+	return reflect.Value{}, nil
+}
+
+// genIndexKey returns a generator of the key of a dynamic selector or
+// index expression: the field name of a selector, or the index value.
+func genIndexKey(n *node) func(*frame) reflect.Value {
+	if n.child[1].kind == identExpr && n.kind == selectorExpr {
+		name := reflect.ValueOf(n.child[1].ident)
+		return func(f *frame) reflect.Value { return name }
+	}
+	return genValue(n.child[1])
+}
+
+// genSetIndexGeneric returns a function setting the element designated by
+// the dynamic selector or index expression n. It is the counterpart of
+// getIndexGeneric: map entries, slice and array elements and fields of
+// struct pointers can be set, the value being converted to the element type.
+func genSetIndexGeneric(n *node) func(*frame, reflect.Value) {
+	value0 := genValue(n.child[0])
+	value1 := genIndexKey(n)
+
+	return func(f *frame, v reflect.Value) {
+		value := concreteValue(value0(f))
 		switch value.Kind() {
 		case reflect.Map:
-			k := value1(f)
-			v := value.MapIndex(k)
-			if v.IsValid() {
-				nvalue := dest(f)
-				ntype := nvalue.Type()
-				cv, err := rconv(v, ntype)
-				if err != nil {
-					panic(n.runErrorf("failed to convert %s to %s", v.Type(), ntype))
-				}
-				nvalue.Set(cv)
+			if value.IsNil() {
+				panic(n.runErrorf("assignment to entry in nil map"))
 			}
-		case reflect.Slice, reflect.Array, reflect.String:
-			i := value1(f)
-			v := value.Index(cast.ToInt(i.Interface()))
-			if v.IsValid() {
-				dest(f).Set(v)
+			typ := value.Type()
+			value.SetMapIndex(n.rconv(value1(f), typ.Key()), n.rconv(v, typ.Elem()))
+			return
+		case reflect.Slice, reflect.Array:
+			i := n.interp.conv.rconvToInt(value1(f))
+			if i < 0 || i >= value.Len() {
+				panic(n.runErrorf("index out of range [%d] with length %d", i, value.Len()))
+			}
+			if e := value.Index(i); e.CanSet() {
+				e.Set(n.rconv(v, e.Type()))
+				return
+			}
+		case reflect.Ptr:
+			if e := fieldByName(value, value1(f)); e.CanSet() {
+				e.Set(n.rconv(v, e.Type()))
+				return
 			}
 		}
-		return tnext
+		typ := "nil"
+		if value.IsValid() {
+			typ = value.Type().String()
+		}
+		panic(n.runErrorf("cannot assign to element of %s", typ))
+	}
+}
+
+// opAssignIndexGeneric sets the exec of the compound assignment n, whose
+// destination is a dynamic selector or index, from the operator generator op.
+// The operation is computed in the destination frame slot, then stored back.
+func opAssignIndexGeneric(n *node, op bltnGenerator) {
+	next := getExec(n.tnext)
+	set := genSetIndexGeneric(n.child[0])
+	value := genValue(n)
+
+	op(n)
+	exec := n.exec
+	n.exec = func(f *frame) bltn {
+		exec(f)
+		set(f, value(f))
+		return next
+	}
+}
+
+// incDecIndexGeneric sets the exec of the increment or decrement statement n,
+// whose operand is a dynamic selector or index. The numeric type of the
+// operand is preserved, other values are converted to a number first.
+func incDecIndexGeneric(n *node, delta int) {
+	next := getExec(n.tnext)
+	value := genValue(n.child[0])
+	set := genSetIndexGeneric(n.child[0])
+
+	n.exec = func(f *frame) bltn {
+		v := concreteValue(value(f))
+		if !v.IsValid() || !isNumber(v.Type()) {
+			v = concreteValue(n.interp.conv.rconvNumber(v))
+		}
+		var r reflect.Value
+		switch t := v.Type(); {
+		case isUint(t):
+			r = reflect.New(t).Elem()
+			r.SetUint(v.Uint() + uint64(delta))
+		case isInt(t):
+			r = reflect.New(t).Elem()
+			r.SetInt(v.Int() + int64(delta))
+		case isFloat(t):
+			r = reflect.New(t).Elem()
+			r.SetFloat(v.Float() + float64(delta))
+		case isComplex(t):
+			r = reflect.New(t).Elem()
+			r.SetComplex(v.Complex() + complex(float64(delta), 0))
+		default:
+			r = n.convFailed(v, reflect.TypeOf(0), errors.New("only numbers support ++ and -- operators"))
+		}
+		set(f, r)
+		return next
+	}
+}
+
+// concreteValue returns the dynamic value held by v, unwrapping interfaces.
+func concreteValue(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		switch vi, ok := v.Interface().(valueInterface); {
+		case ok:
+			v = vi.value
+		case v.Kind() == reflect.Interface:
+			v = v.Elem()
+		default:
+			return v
+		}
+	}
+	return v
+}
+
+// fieldByName returns the field of struct or pointer to struct v named by
+// name, or an invalid value.
+func fieldByName(v, name reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
 	}
+	if v.Kind() != reflect.Struct || name.Kind() != reflect.String {
+		return reflect.Value{}
+	}
+	if f := v.FieldByName(name.String()); f.IsValid() {
+		return f
+	}
+	return v.FieldByName(exportName(name.String()))
 }
 
 // getIndexMap retrieves map value from index.
@@ -1905,6 +2122,7 @@ func getIndexMap(n *node) {
 	value0 := genValue(n.child[0]) // map
 	tnext := getExec(n.tnext)
 	z := reflect.New(n.child[0].typ.frameType().Elem()).Elem()
+	value1 := genValueMapKey(n.child[0], n.child[1]) // map index
 
 	if n.child[1].rval.IsValid() { // constant map index
 		mi := n.child[1].rval
@@ -1931,8 +2149,6 @@ func getIndexMap(n *node) {
 			}
 		}
 	} else {
-		value1 := genValue(n.child[1]) // map index
-
 		switch {
 		case n.fnext != nil:
 			fnext := getExec(n.fnext)
@@ -1959,9 +2175,10 @@ func getIndexMap(n *node) {
 
 // getIndexMap2 retrieves map value from index and set status.
 func getIndexMap2(n *node) {
-	dest := genValue(n.anc.child[0])   // result
-	value0 := genValue(n.child[0])     // map
-	value2 := genValue(n.anc.child[1]) // status
+	dest := genValue(n.anc.child[0])                 // result
+	value0 := genValue(n.child[0])                   // map
+	value2 := genValue(n.anc.child[1])               // status
+	value1 := genValueMapKey(n.child[0], n.child[1]) // map index
 	next := getExec(n.tnext)
 	doValue := n.anc.child[0].ident != "_"
 	doStatus := n.anc.child[1].ident != "_"
@@ -1992,7 +2209,6 @@ func getIndexMap2(n *node) {
 			}
 		}
 	} else {
-		value1 := genValue(n.child[1]) // map index
 		switch {
 		case !doValue:
 			n.exec = func(f *frame) bltn {
@@ -2064,6 +2280,7 @@ func getMethodByName(n *node) {
 			val = v
 		}
 		if met := val.value.MethodByName(name); met.IsValid() {
+			n.checkDynMethod(val.value.Type())
 			getFrame(f, l).data[i] = met
 			return next
 		}
@@ -2275,6 +2492,11 @@ func neg(n *node) {
 	isInterface := n.typ.TypeOf().Kind() == reflect.Interface
 
 	switch n.typ.TypeOf().Kind() {
+	case reflect.Interface:
+		n.exec = func(f *frame) bltn {
+			dest(f).Set(negDynamic(n, value(f)))
+			return next
+		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if isInterface {
 			n.exec = func(f *frame) bltn {
@@ -2331,6 +2553,14 @@ func pos(n *node) {
 	value := genValue(n.child[0])
 	next := getExec(n.tnext)
 
+	if n.typ.TypeOf().Kind() == reflect.Interface {
+		n.exec = func(f *frame) bltn {
+			dest(f).Set(posDynamic(n, value(f)))
+			return next
+		}
+		return
+	}
+
 	n.exec = func(f *frame) bltn {
 		dest(f).Set(value(f))
 		return next
@@ -2345,6 +2575,11 @@ func bitNot(n *node) {
 	isInterface := n.typ.TypeOf().Kind() == reflect.Interface
 
 	switch typ.Kind() {
+	case reflect.Interface:
+		n.exec = func(f *frame) bltn {
+			dest(f).Set(bitNotDynamic(n, value(f)))
+			return next
+		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if isInterface {
 			n.exec = func(f *frame) bltn {
@@ -2373,65 +2608,49 @@ func bitNot(n *node) {
 }
 
 func land(n *node) {
-	value0 := genValue(n.child[0])
-	value1 := genValue(n.child[1])
+	truthy0 := genValueTruthy(n.child[0], genValue(n.child[0]))
+	truthy1 := genValueTruthy(n.child[1], genValue(n.child[1]))
 	tnext := getExec(n.tnext)
 	dest := genValue(n)
-	isInterface := n.typ.TypeOf().Kind() == reflect.Interface
 
 	if n.fnext != nil {
 		fnext := getExec(n.fnext)
 		n.exec = func(f *frame) bltn {
-			if rconvToBool(value0(f)) && rconvToBool(value1(f)) {
-				rconvAndSet(dest(f), reflect.ValueOf(true))
+			if truthy0(f) && truthy1(f) {
+				n.rconvAndSet(dest(f), reflect.ValueOf(true))
 				return tnext
 			}
-			rconvAndSet(dest(f), reflect.ValueOf(false))
+			n.rconvAndSet(dest(f), reflect.ValueOf(false))
 			return fnext
 		}
 		return
 	}
-	if isInterface {
-		n.exec = func(f *frame) bltn {
-			rconvAndSet(dest(f), reflect.ValueOf(rconvToBool(value0(f)) && rconvToBool(value1(f))))
-			return tnext
-		}
-		return
-	}
 	n.exec = func(f *frame) bltn {
-		rconvAndSet(dest(f), reflect.ValueOf(rconvToBool(value0(f)) && rconvToBool(value1(f))))
+		n.rconvAndSet(dest(f), reflect.ValueOf(truthy0(f) && truthy1(f)))
 		return tnext
 	}
 }
 
 func lor(n *node) {
-	value0 := genValue0(n.child[0])
-	value1 := genValue0(n.child[1])
+	truthy0 := genValueTruthy(n.child[0], genValue0(n.child[0]))
+	truthy1 := genValueTruthy(n.child[1], genValue0(n.child[1]))
 	tnext := getExec(n.tnext)
 	dest := genValue(n)
-	isInterface := n.typ.TypeOf().Kind() == reflect.Interface
 
 	if n.fnext != nil {
 		fnext := getExec(n.fnext)
 		n.exec = func(f *frame) bltn {
-			if rconvToBool(value0(f)) || rconvToBool(value1(f)) {
-				rconvAndSet(dest(f), reflect.ValueOf(true))
+			if truthy0(f) || truthy1(f) {
+				n.rconvAndSet(dest(f), reflect.ValueOf(true))
 				return tnext
 			}
-			rconvAndSet(dest(f), reflect.ValueOf(false))
+			n.rconvAndSet(dest(f), reflect.ValueOf(false))
 			return fnext
 		}
 		return
 	}
-	if isInterface {
-		n.exec = func(f *frame) bltn {
-			rconvAndSet(dest(f), reflect.ValueOf(rconvToBool(value0(f)) || rconvToBool(value1(f))))
-			return tnext
-		}
-		return
-	}
 	n.exec = func(f *frame) bltn {
-		rconvAndSet(dest(f), reflect.ValueOf(rconvToBool(value0(f)) || rconvToBool(value1(f))))
+		n.rconvAndSet(dest(f), reflect.ValueOf(truthy0(f) || truthy1(f)))
 		return tnext
 	}
 }
@@ -2447,18 +2666,10 @@ func nop(n *node) {
 func branch(n *node) {
 	tnext := getExec(n.tnext)
 	fnext := getExec(n.fnext)
-	value := genValue(n)
+	truthy := genValueTruthy(n, genValue(n))
 
 	n.exec = func(f *frame) bltn {
-		rval := value(f)
-		rtype := rval.Type()
-		btype := reflect.ValueOf(false).Type()
-		var err error
-		rval, err = rconv(rval, btype)
-		if err != nil {
-			panic(n.runErrorf("failed to convert %s to %s", rtype, btype))
-		}
-		if rval.Bool() {
+		if truthy(f) {
 			return tnext
 		}
 		return fnext
@@ -2501,9 +2712,12 @@ func _return(n *node) {
 			}
 			fallthrough
 		default:
-			if c.typ.untyped {
-				values[i] = genValueAs(c, def.typ.ret[i].TypeOf())
-			} else {
+			switch {
+			case c.typ.untyped:
+				values[i] = genValueAs(c, t.TypeOf())
+			case needsImplicitConv(c, t):
+				values[i] = genValueImplicit(c, t.frameType())
+			default:
 				values[i] = genValue(c)
 			}
 		}
@@ -2514,7 +2728,7 @@ func _return(n *node) {
 		n.exec = nil
 	case 1:
 		switch {
-		case !child[0].rval.IsValid() && child[0].kind == binaryExpr:
+		case !child[0].rval.IsValid() && child[0].kind == binaryExpr && !needsImplicitConv(child[0], def.typ.ret[0]):
 			// No additional runtime operation is necessary for constants (not in frame) or
 			// binary expressions (stored directly at the right location in frame).
 			n.exec = nil
@@ -2585,16 +2799,10 @@ func arrayLit(n *node) {
 		for i, v := range values {
 			l := a.Index(index[i])
 			r := v(f)
-			err := rconvAndSet(l, r)
-			if err != nil {
-				panic(n.runErrorf("failed to convert %s to %s", r.Type(), l.Type()))
-			}
+			n.rconvAndSet(l, r)
 		}
 		vleft := value(f)
-		err := rconvAndSet(vleft, a)
-		if err != nil {
-			panic(n.runErrorf("failed to convert %s to %s", a.Type(), vleft.Type()))
-		}
+		n.rconvAndSet(vleft, a)
 		return next
 	}
 }
@@ -2610,8 +2818,8 @@ func mapLit(n *node) {
 	keys := make([]func(*frame) reflect.Value, len(child))
 	values := make([]func(*frame) reflect.Value, len(child))
 	for i, c := range child {
-		keys[i] = genDestValue(n.typ.key, c.child[0])
-		values[i] = genDestValue(n.typ.val, c.child[1])
+		keys[i] = genDestValueImplicit(n.typ.key, c.child[0])
+		values[i] = genDestValueImplicit(n.typ.val, c.child[1])
 	}
 
 	n.exec = func(f *frame) bltn {
@@ -2638,9 +2846,14 @@ func compositeBinMap(n *node) {
 		convertLiteralValue(c.child[0], typ.Key())
 		convertLiteralValue(c.child[1], typ.Elem())
 		keys[i] = genValue(c.child[0])
+		if needsImplicitConv(c.child[0], valueTOf(typ.Key())) {
+			keys[i] = genValueImplicit(c.child[0], typ.Key())
+		}
 
 		if isFuncSrc(c.child[1].typ) {
 			values[i] = genFunctionWrapper(c.child[1])
+		} else if needsImplicitConv(c.child[1], valueTOf(typ.Elem())) {
+			values[i] = genValueImplicit(c.child[1], typ.Elem())
 		} else {
 			values[i] = genValue(c.child[1])
 		}
@@ -2673,10 +2886,16 @@ func compositeBinSlice(n *node) {
 		if c.kind == keyValueExpr {
 			convertLiteralValue(c.child[1], rtype)
 			values[i] = genValue(c.child[1])
+			if needsImplicitConv(c.child[1], valueTOf(rtype)) {
+				values[i] = genValueImplicit(c.child[1], rtype)
+			}
 			index[i] = int(vInt(c.child[0].rval))
 		} else {
 			convertLiteralValue(c, rtype)
 			values[i] = genValue(c)
+			if needsImplicitConv(c, valueTOf(rtype)) {
+				values[i] = genValueImplicit(c, rtype)
+			}
 			index[i] = prev
 		}
 		prev = index[i] + 1
@@ -2723,6 +2942,8 @@ func doCompositeBinStruct(n *node, hasType bool) {
 				convertLiteralValue(c.child[1], sf.Type)
 				if isFuncSrc(c.child[1].typ) {
 					values[i] = genFunctionWrapper(c.child[1])
+				} else if needsImplicitConv(c.child[1], valueTOf(sf.Type)) {
+					values[i] = genValueImplicit(c.child[1], sf.Type)
 				} else {
 					values[i] = genValue(c.child[1])
 				}
@@ -2733,8 +2954,13 @@ func doCompositeBinStruct(n *node, hasType bool) {
 				convertLiteralValue(c.child[1], typ.Field(i).Type)
 				values[i] = genFunctionWrapper(c.child[1])
 			} else {
-				convertLiteralValue(c, typ.Field(i).Type)
-				values[i] = genValue(c)
+				ft := typ.Field(i).Type
+				convertLiteralValue(c, ft)
+				if needsImplicitConv(c, valueTOf(ft)) {
+					values[i] = genValueImplicit(c, ft)
+				} else {
+					values[i] = genValue(c)
+				}
 			}
 		}
 	}
@@ -2808,6 +3034,8 @@ func doComposite(n *node, hasType bool, keyed bool) {
 			values[fieldIndex] = genValueInterface(val)
 		case isInterface(ft):
 			values[fieldIndex] = genInterfaceWrapper(val, rft)
+		case needsImplicitConv(val, ft):
+			values[fieldIndex] = genValueImplicit(val, rft)
 		default:
 			values[fieldIndex] = genValue(val)
 		}
@@ -2996,72 +3224,130 @@ func rangeMap(n *node) {
 	}
 }
 
+// rangeDynamic ranges over a value held in an interface, whose kind is only known at runtime.
 func rangeDynamic(n *node) {
 	index0 := n.child[0].findex // index location in frame
 	index2 := index0 - 1        // iterator for range, always just behind index0
+	index1 := -1                // value location in frame, if any
 	fnext := getExec(n.fnext)
 	tnext := getExec(n.tnext)
 
 	var value func(*frame) reflect.Value
 	if len(n.child) == 4 {
-		index1 := n.child[1].findex
+		index1 = n.child[1].findex
 		value = genValue(n.child[2])
-		n.exec = func(f *frame) bltn {
-			iterable := f.data[index2]
-			iter, ok := iterable.Interface().(*reflect.MapIter)
-			if ok {
-				// map
-				if !iter.Next() {
-					return fnext
-				}
-				f.data[index0].Set(iter.Key())
-				f.data[index1].Set(iter.Value())
-				return tnext
-			} else {
-				iterable = iterable.Elem()
-				ival := f.data[index0]
-				eval := f.data[index1]
-				var idx int
-				if ival.IsZero() {
-					idx = 0
-				} else {
-					idx = int(ival.Elem().Int() + 1)
-				}
-				if iterable.Len() <= idx {
-					return fnext
-				}
-				el := iterable.Index(idx)
-				ival.Set(reflect.ValueOf(idx))
-				eval.Set(el)
-				return tnext
-			}
-		}
 	} else {
 		value = genValue(n.child[1])
-		n.exec = func(f *frame) bltn {
-			iter := f.data[index2].Interface().(*reflect.MapIter)
-			if !iter.Next() {
-				return fnext
-			}
-			f.data[index0].Set(iter.Key())
-			return tnext
+	}
+
+	n.exec = func(f *frame) bltn {
+		k, v, ok := f.data[index2].Interface().(rangeIterator)(f)
+		if !ok {
+			return fnext
+		}
+		f.data[index0].Set(k)
+		if index1 >= 0 {
+			f.data[index1].Set(v)
 		}
+		return tnext
 	}
 
 	// Init sequence
 	next := n.exec
 	n.child[0].exec = func(f *frame) bltn {
-		val := value(f)
-		val = rconvToConcrete(val)
-		if val.Kind() == reflect.Map {
-			f.data[index2].Set(reflect.ValueOf(val.MapRange()))
-		} else {
-			f.data[index2].Set(val)
-		}
+		f.data[index2].Set(reflect.ValueOf(n.rangeIterator(value(f), index1 >= 0)))
 		return next
 	}
 }
 
+// A rangeIterator returns the next key and value of a range over a dynamic
+// value, or false when the range is complete.
+type rangeIterator func(f *frame) (key, value reflect.Value, ok bool)
+
+// rangeIterator returns an iterator over v according to its concrete kind:
+// maps yield keys and values, arrays, slices and pointers to arrays yield
+// indexes and elements, strings yield byte positions and runes, channels
+// yield received values, and structs or pointers to structs yield field names
+// and values. A nil value yields nothing.
+func (n *node) rangeIterator(v reflect.Value, withValue bool) rangeIterator {
+	v = concreteValue(v)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && (v.Elem().Kind() == reflect.Array || v.Elem().Kind() == reflect.Struct) {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return func(*frame) (reflect.Value, reflect.Value, bool) { return v, v, false }
+
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan:
+		if v.IsNil() {
+			return func(*frame) (reflect.Value, reflect.Value, bool) { return v, v, false }
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		iter := v.MapRange()
+		return func(*frame) (reflect.Value, reflect.Value, bool) {
+			if !iter.Next() {
+				return v, v, false
+			}
+			return iter.Key(), iter.Value(), true
+		}
+
+	case reflect.Array, reflect.Slice:
+		i := -1
+		return func(*frame) (reflect.Value, reflect.Value, bool) {
+			if i++; i >= v.Len() {
+				return v, v, false
+			}
+			return reflect.ValueOf(i), v.Index(i), true
+		}
+
+	case reflect.String:
+		s, pos := v.String(), 0
+		return func(*frame) (reflect.Value, reflect.Value, bool) {
+			if pos >= len(s) {
+				return v, v, false
+			}
+			r, size := utf8.DecodeRuneInString(s[pos:])
+			k := reflect.ValueOf(pos)
+			pos += size
+			return k, reflect.ValueOf(r), true
+		}
+
+	case reflect.Chan:
+		if withValue {
+			panic(n.runErrorf("range over %v permits only one iteration variable", v.Type()))
+		}
+		return func(f *frame) (reflect.Value, reflect.Value, bool) {
+			f.mutex.RLock()
+			done := f.done
+			f.mutex.RUnlock()
+
+			chosen, r, ok := reflect.Select([]reflect.SelectCase{done, {Dir: reflect.SelectRecv, Chan: v}})
+			if chosen == 0 || !ok {
+				return v, v, false
+			}
+			return r, r, true
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		i := -1
+		return func(*frame) (reflect.Value, reflect.Value, bool) {
+			for i++; i < t.NumField(); i++ {
+				if sf := t.Field(i); sf.PkgPath == "" {
+					return reflect.ValueOf(sourceFieldName(t, sf)), v.Field(i), true
+				}
+			}
+			return v, v, false
+		}
+	}
+
+	panic(n.runErrorf("cannot range over %v", v.Type()))
+}
+
 func _case(n *node) {
 	tnext := getExec(n.tnext)
 
@@ -3239,11 +3525,7 @@ func _case(n *node) {
 				v0Type := v0.Type()
 				v1Type := v1.Type()
 				if !v0Type.AssignableTo(v1Type) {
-					var err error
-					v0, err = rconv(v0, v1Type)
-					if err != nil {
-						panic(n.runErrorf("failed to convert %s to %s", v0Type, v1Type))
-					}
+					v0 = n.rconv(v0, v1Type)
 				}
 				if v0.Interface() == v1.Interface() {
 					return tnext
@@ -3291,6 +3573,10 @@ func appendSlice(n *node) {
 }
 
 func _append(n *node) {
+	if isInterface(n.child[1].typ) {
+		appendDynamic(n)
+		return
+	}
 	if len(n.child) == 3 {
 		c1, c2 := n.child[1], n.child[2]
 		if (c1.typ.cat == valueT || c2.typ.cat == valueT) && c1.typ.rtype == c2.typ.rtype ||
@@ -3346,12 +3632,7 @@ func _append(n *node) {
 				deltype = dtype.Elem()
 			}
 			for i, v := range values {
-				sval := v(f)
-				s, err := rconv(sval, deltype)
-				if err != nil {
-					panic(n.runErrorf("failed to convert %s to %s", sval.Type(), dtype.Elem()))
-				}
-				sl[i] = s
+				sl[i] = n.rconv(v(f), deltype)
 			}
 			dest(f).Set(reflect.Append(dslice, sl...))
 			return next
@@ -3386,21 +3667,69 @@ func _append(n *node) {
 				deltype = dtype.Elem()
 			}
 
-			s, err := rconv(sval, deltype)
-			if err != nil {
-				panic(n.runErrorf("failed to convert %s to %s", sval.Type(), dtype.Elem()))
-			}
+			s := n.rconv(sval, deltype)
 			dest(f).Set(reflect.Append(dslice, s))
 			return next
 		}
 	}
 }
 
+// appendDynamic appends to a slice held in an interface, converting the
+// appended values to its element type. A nil value is appended to as an
+// []interface{}.
+func appendDynamic(n *node) {
+	dest := genValueOutput(n, n.typ.frameType())
+	value := genValue(n.child[1])
+	args := make([]func(*frame) reflect.Value, len(n.child)-2)
+	for i, c := range n.child[2:] {
+		args[i] = genValue(c)
+	}
+	ellipsis := n.action == aCallSlice
+	next := getExec(n.tnext)
+
+	n.exec = func(f *frame) bltn {
+		s := concreteValue(value(f))
+		switch s.Kind() {
+		case reflect.Invalid:
+			s = reflect.ValueOf([]interface{}(nil))
+		case reflect.Slice:
+		default:
+			panic(n.runErrorf("first argument to append must be slice; have %v", s.Type()))
+		}
+		etyp := s.Type().Elem()
+		for _, arg := range args {
+			v := arg(f)
+			if !ellipsis {
+				s = reflect.Append(s, n.rconv(v, etyp))
+				continue
+			}
+			v = concreteValue(v)
+			switch {
+			case v.Kind() == reflect.String && etyp.Kind() == reflect.Uint8:
+				v = reflect.ValueOf([]byte(v.String()))
+			case !v.IsValid():
+				continue
+			case v.Kind() != reflect.Slice && v.Kind() != reflect.Array:
+				panic(n.runErrorf("cannot use %v as slice in append", v.Type()))
+			}
+			for i := 0; i < v.Len(); i++ {
+				s = reflect.Append(s, n.rconv(v.Index(i), etyp))
+			}
+		}
+		dest(f).Set(s)
+		return next
+	}
+}
+
 func _cap(n *node) {
 	dest := genValueOutput(n, reflect.TypeOf(int(0)))
 	value := genValue(n.child[1])
 	next := getExec(n.tnext)
 
+	if isInterface(n.child[1].typ) {
+		value = genValueDynamicLen(n, value, bltnCap)
+	}
+
 	if wantEmptyInterface(n) {
 		n.exec = func(f *frame) bltn {
 			dest(f).Set(reflect.ValueOf(value(f).Cap()))
@@ -3505,9 +3834,23 @@ func _real(n *node) {
 func _delete(n *node) {
 	value0 := genValue(n.child[1]) // map
 	value1 := genValue(n.child[2]) // key
-	in := []func(*frame) reflect.Value{value0, value1}
 	var z reflect.Value
 
+	if n.child[1].typ.TypeOf().Kind() == reflect.Interface {
+		// Map held in an interface, the key is converted to the dynamic key type.
+		in := []func(*frame) reflect.Value{value0, value1}
+		genBuiltinDeferWrapper(n, in, nil, func(args []reflect.Value) []reflect.Value {
+			m := concreteValue(args[0])
+			if m.Kind() != reflect.Map {
+				panic(n.runErrorf("first argument to delete must be map; have %v", dynamicType(args[0])))
+			}
+			m.SetMapIndex(n.rconv(args[1], m.Type().Key()), z)
+			return nil
+		})
+		return
+	}
+
+	in := []func(*frame) reflect.Value{value0, genValueMapKey(n.child[1], n.child[2])}
 	genBuiltinDeferWrapper(n, in, nil, func(args []reflect.Value) []reflect.Value {
 		args[0].SetMapIndex(args[1], z)
 		return nil
@@ -3546,6 +3889,9 @@ func _len(n *node) {
 			return v
 		}
 	}
+	if isInterface(n.child[1].typ) {
+		value = genValueDynamicLen(n, value, bltnLen)
+	}
 	next := getExec(n.tnext)
 
 	if wantEmptyInterface(n) {
@@ -3561,6 +3907,29 @@ func _len(n *node) {
 	}
 }
 
+// genValueDynamicLen returns a generator of the concrete value held in an
+// interface, for builtin name (len or cap) to apply to it. A nil value is
+// substituted with an empty slice, and a pointer to array with the array.
+func genValueDynamicLen(n *node, value func(*frame) reflect.Value, name string) func(*frame) reflect.Value {
+	return func(f *frame) reflect.Value {
+		v := concreteValue(value(f))
+		if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Array {
+			return reflect.Zero(v.Type().Elem())
+		}
+		switch v.Kind() {
+		case reflect.Invalid:
+			return reflect.ValueOf([]interface{}(nil))
+		case reflect.Array, reflect.Slice, reflect.Chan:
+			return v
+		case reflect.Map, reflect.String:
+			if name == bltnLen {
+				return v
+			}
+		}
+		panic(n.runErrorf("invalid argument for %s: %v", name, v.Type()))
+	}
+}
+
 func _new(n *node) {
 	next := getExec(n.tnext)
 	typ := n.child[1].typ.TypeOf()
@@ -3695,6 +4064,7 @@ func recv(n *node) {
 				if chosen == 0 {
 					return nil
 				}
+				getFrame(f, l).data[i] = v
 				if v.Bool() {
 					return tnext
 				}
@@ -3792,11 +4162,7 @@ func convertLiteralValue(n *node, t reflect.Type) {
 	case n.rval.IsValid():
 		// Convert constant value to target type.
 		convertConstantValue(n)
-		var err error
-		n.rval, err = rconv(n.rval, t)
-		if err != nil {
-			panic(n.runErrorf("failed to convert %s to %s", n.rval.Type(), t))
-		}
+		n.rval = n.rconv(n.rval, t)
 	default:
 		// Create a zero value of target type.
 		n.rval = reflect.New(t).Elem()
@@ -3834,13 +4200,7 @@ func convertConstantValue(n *node) {
 		v = reflect.ValueOf(complex(r, i))
 	}
 
-	var err error
-	ntype := n.typ.TypeOf()
-	v, err = rconv(v, ntype)
-	if err != nil {
-		panic(n.runErrorf("failed to convert %s to %s", v.Type(), ntype))
-	}
-	n.rval = v
+	n.rval = n.rconv(v, n.typ.TypeOf())
 }
 
 // Write to a channel.
@@ -3848,7 +4208,7 @@ func send(n *node) {
 	next := getExec(n.tnext)
 	c0, c1 := n.child[0], n.child[1]
 	value0 := genValue(c0) // Send channel.
-	value1 := genDestValue(c0.typ.val, c1)
+	value1 := genDestValueImplicit(c0.typ.val, c1)
 
 	if !n.interp.cancelChan {
 		// Send is non-cancellable, has the least overhead.
@@ -4076,13 +4436,13 @@ func isNil(n *node) {
 		if !isInterfaceSrc(c.typ) {
 			if isInterface {
 				n.exec = func(f *frame) bltn {
-					dest(f).Set(reflect.ValueOf(rconvToNil(value(f))).Convert(typ))
+					dest(f).Set(reflect.ValueOf(n.interp.conv.rconvToNil(value(f))).Convert(typ))
 					return tnext
 				}
 				return
 			}
 			n.exec = func(f *frame) bltn {
-				dest(f).SetBool(rconvToNil(value(f)))
+				dest(f).SetBool(n.interp.conv.rconvToNil(value(f)))
 				return tnext
 			}
 			return
@@ -4094,7 +4454,7 @@ func isNil(n *node) {
 				if vi, ok := v.Interface().(valueInterface); ok {
 					r = vi == valueInterface{} || vi.node.kind == basicLit && vi.node.typ.cat == nilT
 				} else {
-					r = rconvToNil(v)
+					r = n.interp.conv.rconvToNil(v)
 				}
 				dest(f).Set(reflect.ValueOf(r).Convert(typ))
 				return tnext
@@ -4107,7 +4467,7 @@ func isNil(n *node) {
 			if vi, ok := v.Interface().(valueInterface); ok {
 				r = vi == valueInterface{} || vi.node.kind == basicLit && vi.node.typ.cat == nilT
 			} else {
-				r = rconvToNil(v)
+				r = n.interp.conv.rconvToNil(v)
 			}
 			dest(f).SetBool(r)
 			return tnext
@@ -4120,7 +4480,7 @@ func isNil(n *node) {
 	if !isInterfaceSrc(c.typ) {
 		n.exec = func(f *frame) bltn {
 			v := value(f)
-			if rconvToNil(v) {
+			if n.interp.conv.rconvToNil(v) {
 				dest(f).SetBool(true)
 				return tnext
 			}
@@ -4140,7 +4500,7 @@ func isNil(n *node) {
 			dest(f).SetBool(false)
 			return fnext
 		}
-		if rconvToNil(v) {
+		if n.interp.conv.rconvToNil(v) {
 			dest(f).SetBool(true)
 			return tnext
 		}
@@ -4171,13 +4531,13 @@ func isNotNil(n *node) {
 		if isInterfaceSrc(c.typ) {
 			if isInterface {
 				n.exec = func(f *frame) bltn {
-					dest(f).Set(reflect.ValueOf(!rconvToNil(value(f))).Convert(typ))
+					dest(f).Set(reflect.ValueOf(!n.interp.conv.rconvToNil(value(f))).Convert(typ))
 					return tnext
 				}
 				return
 			}
 			n.exec = func(f *frame) bltn {
-				dest(f).SetBool(!rconvToNil(value(f)))
+				dest(f).SetBool(!n.interp.conv.rconvToNil(value(f)))
 				return tnext
 			}
 			return
@@ -4190,7 +4550,7 @@ func isNotNil(n *node) {
 				if vi, ok := v.Interface().(valueInterface); ok {
 					r = (vi == valueInterface{} || vi.node.kind == basicLit && vi.node.typ.cat == nilT)
 				} else {
-					r = rconvToNil(v)
+					r = n.interp.conv.rconvToNil(v)
 				}
 				dest(f).Set(reflect.ValueOf(!r).Convert(typ))
 				return tnext
@@ -4203,7 +4563,7 @@ func isNotNil(n *node) {
 			if vi, ok := v.Interface().(valueInterface); ok {
 				r = (vi == valueInterface{} || vi.node.kind == basicLit && vi.node.typ.cat == nilT)
 			} else {
-				r = rconvToNil(v)
+				r = n.interp.conv.rconvToNil(v)
 			}
 			dest(f).SetBool(!r)
 			return tnext
@@ -4216,7 +4576,7 @@ func isNotNil(n *node) {
 	if isInterfaceSrc(c.typ) {
 		n.exec = func(f *frame) bltn {
 			v := value(f)
-			if rconvToNil(v) {
+			if n.interp.conv.rconvToNil(v) {
 				dest(f).SetBool(false)
 				return fnext
 			}
@@ -4236,7 +4596,7 @@ func isNotNil(n *node) {
 			dest(f).SetBool(true)
 			return tnext
 		}
-		if rconvToNil(v) {
+		if n.interp.conv.rconvToNil(v) {
 			dest(f).SetBool(false)
 			return fnext
 		}
@@ -0,0 +1,206 @@
+package interp
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Runner executes a compiled Program many times, possibly concurrently.
+//
+// Each execution runs on its own copy of the global frame of the interpreter,
+// so the global variables set by an execution are neither seen by the other
+// executions nor by the interpreter. The copy is shallow: the values referred
+// to by global pointers, maps, slices and channels are shared.
+//
+// Inputs are given as values of global variables of the program package, set
+// after their initialization, and outputs are read from the global variables
+// at the end of the execution.
+type Runner struct {
+	interp *Interpreter
+	prog   *Program
+	scope  *scope                     // program package scope
+	vars   *node                      // global variables initialization, or nil
+	value  func(*frame) reflect.Value // last result of the program
+}
+
+// NewRunner returns a Runner of the compiled program p. The program is not
+// executed, and must not be passed to Execute afterwards.
+func (interp *Interpreter) NewRunner(p *Program) (*Runner, error) {
+	if err := genRun(p.root); err != nil {
+		return nil, err
+	}
+	sc := interp.scopes[p.pkgName]
+	vars, err := genGlobalVars([]*node{p.root}, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	interp.resolveTypes()
+
+	interp.frame.mutex.Lock()
+	interp.resizeFrame()
+	interp.frame.mutex.Unlock()
+
+	return &Runner{interp: interp, prog: p, scope: sc, vars: vars, value: genValue(p.root)}, nil
+}
+
+// resolveTypes computes the runtime types of all the compiled nodes, which
+// are otherwise computed and cached on first use, so that the concurrent
+// executions only read them.
+func (interp *Interpreter) resolveTypes() {
+	interp.mutex.RLock()
+	roots := interp.roots
+	interp.mutex.RUnlock()
+
+	for _, root := range roots {
+		root.Walk(func(n *node) bool {
+			if t := n.typ; t != nil && !t.incomplete {
+				switch t.cat {
+				case builtinT, binPkgT, srcPkgT, nilT:
+				default:
+					t.TypeOf()
+				}
+			}
+			return true
+		}, nil)
+	}
+}
+
+// Run executes the program, with the global variables named by the keys of
+// vars set to the values, and returns the last result computed by the program
+// and the global variables and constants of its package, at the end of the
+// execution. The main function is executed if present. Values not assignable
+// to their variable are converted implicitly, except in strict mode.
+func (r *Runner) Run(vars map[string]interface{}) (res reflect.Value, globals map[string]reflect.Value, err error) {
+	defer r.interp.recoverPanic(&err)
+
+	if err = r.interp.goroutineErr(); err != nil {
+		return res, nil, err
+	}
+	f, err := r.init(vars)
+	if err != nil {
+		return res, nil, err
+	}
+	runCfg(r.prog.root.start, f, r.prog.root, nil)
+	for _, n := range r.prog.init {
+		r.interp.run(n, f)
+	}
+
+	res = r.value(f)
+	if res.IsValid() {
+		if n, ok := res.Interface().(*node); ok {
+			res = genFunctionWrapper(n)(f)
+		}
+	}
+	return res, r.globals(f), nil
+}
+
+// Call calls the function name of the program package with args, and returns
+// its results. The global variables named by the keys of vars are set to the
+// values, and the init functions are executed, but not the main function.
+func (r *Runner) Call(name string, vars map[string]interface{}, args ...interface{}) (res []reflect.Value, err error) {
+	defer r.interp.recoverPanic(&err)
+
+	if err = r.interp.goroutineErr(); err != nil {
+		return nil, err
+	}
+	sym := r.lookup(name)
+	if sym == nil || sym.kind != funcSym {
+		return nil, fmt.Errorf("undefined function: %s", name)
+	}
+	f, err := r.init(vars)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range r.prog.init {
+		if n.child[1].ident != mainID {
+			r.interp.run(n, f)
+		}
+	}
+
+	fn := genFunctionWrapper(sym.node)(f)
+	ft := fn.Type()
+	if len(args) != ft.NumIn() && !(ft.IsVariadic() && len(args) >= ft.NumIn()-1) {
+		return nil, fmt.Errorf("%s: got %d arguments, want %d", name, len(args), ft.NumIn())
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var t reflect.Type
+		if last := ft.NumIn() - 1; ft.IsVariadic() && i >= last {
+			t = ft.In(last).Elem()
+		} else {
+			t = ft.In(i)
+		}
+		if in[i], err = r.interp.bindValue(a, t); err != nil {
+			return nil, fmt.Errorf("%s: argument %d: %w", name, i, err)
+		}
+	}
+	return fn.Call(in), nil
+}
+
+// init returns a new copy of the global frame, on which the global variables
+// of the program are initialized, then set to vars.
+func (r *Runner) init(vars map[string]interface{}) (*frame, error) {
+	interp := r.interp
+	interp.frame.mutex.RLock()
+	f := newFrame(nil, len(interp.frame.data), interp.runid())
+	f.meter = interp.newMeter(interp.budget)
+	f.allocs = interp.newAllocMeter()
+	for i, v := range interp.frame.data {
+		if !v.IsValid() || !v.CanInterface() {
+			f.data[i] = v
+			continue
+		}
+		f.data[i] = reflect.New(v.Type()).Elem()
+		f.data[i].Set(v)
+	}
+	interp.frame.mutex.RUnlock()
+
+	interp.mutex.RLock()
+	f.done = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(interp.done)}
+	interp.mutex.RUnlock()
+
+	if r.vars != nil {
+		runCfg(r.vars.start, f, r.vars, nil)
+	}
+	for name, v := range vars {
+		sym := r.lookup(name)
+		if sym == nil || sym.kind != varSym {
+			return nil, fmt.Errorf("undefined global variable: %s", name)
+		}
+		dest := f.data[sym.index]
+		cv, err := interp.bindValue(v, dest.Type())
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", name, err)
+		}
+		dest.Set(cv)
+	}
+	return f, nil
+}
+
+// globals returns the global variables and constants of the program package
+// in frame f.
+func (r *Runner) globals(f *frame) map[string]reflect.Value {
+	syms := map[string]reflect.Value{}
+	r.interp.mutex.RLock()
+	defer r.interp.mutex.RUnlock()
+
+	for n, s := range r.scope.sym {
+		switch s.kind {
+		case constSym:
+			syms[n] = s.rval
+		case varSym:
+			if s.index < len(f.data) {
+				syms[n] = f.data[s.index]
+			}
+		}
+	}
+	return syms
+}
+
+// lookup returns the symbol name of the program package, or nil.
+func (r *Runner) lookup(name string) *symbol {
+	r.interp.mutex.RLock()
+	defer r.interp.mutex.RUnlock()
+	return r.scope.sym[name]
+}
@@ -0,0 +1,304 @@
+package interp_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/traefik/yaegi/interp"
+	"github.com/traefik/yaegi/stdlib"
+)
+
+// The tests of this file execute programs concurrently, and are meant to be
+// run with the race detector.
+
+const runners = 32
+
+func newRunner(t *testing.T, i *interp.Interpreter, src string) *interp.Runner {
+	t.Helper()
+	p, err := i.Compile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := i.NewRunner(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// concurrently calls f with the indexes 0 to n-1 in parallel goroutines.
+func concurrently(n int, f func(k int)) {
+	var wg sync.WaitGroup
+	for k := 0; k < n; k++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			f(k)
+		}(k)
+	}
+	wg.Wait()
+}
+
+func TestRunnerRun(t *testing.T) {
+	i := interp.New(interp.Options{})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	r := newRunner(t, i, `package main
+
+import "strings"
+
+var (
+	name  string
+	count = 2
+	words []string
+	out   string
+)
+
+func repeat(s string) {
+	for j := 0; j < count; j++ {
+		words = append(words, s)
+	}
+}
+
+func main() {
+	repeat(name)
+	count++
+	out = strings.Join(words, "-")
+}
+`)
+
+	concurrently(runners, func(k int) {
+		name := fmt.Sprint("w", k)
+		_, globals, err := r.Run(map[string]interface{}{"name": name, "count": k % 3})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		want := strings.TrimSuffix(strings.Repeat(name+"-", k%3), "-")
+		if got := globals["out"].String(); got != want {
+			t.Errorf("run %d: got out %q, want %q", k, got, want)
+		}
+		if got := globals["count"].Int(); got != int64(k%3+1) {
+			t.Errorf("run %d: got count %d, want %d", k, got, k%3+1)
+		}
+	})
+
+	// The executions leave the interpreter globals untouched.
+	if g := i.Globals(); g["out"].String() != "" || g["count"].Int() != 0 {
+		t.Errorf("got interpreter globals out %v count %v, want zero values", g["out"], g["count"])
+	}
+}
+
+func TestRunnerRunStatements(t *testing.T) {
+	i := interp.New(interp.Options{})
+	eval(t, i, `var x, y int`)
+	eval(t, i, `var total = 100`)
+	r := newRunner(t, i, `total += x * y; total`)
+
+	concurrently(runners, func(k int) {
+		res, globals, err := r.Run(map[string]interface{}{"x": k, "y": "2"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := res.Interface(), 100+2*k; got != want {
+			t.Errorf("run %d: got %v, want %v", k, got, want)
+		}
+		if got := globals["total"].Interface(); got != 100+2*k {
+			t.Errorf("run %d: got total %v, want %v", k, got, 100+2*k)
+		}
+	})
+	if got := i.Globals()["total"].Interface(); got != 100 {
+		t.Errorf("got interpreter total %v, want 100", got)
+	}
+}
+
+func TestRunnerCall(t *testing.T) {
+	i := interp.New(interp.Options{})
+	r := newRunner(t, i, `package main
+
+type Counter struct{ hits map[string]int }
+
+var (
+	prefix string
+	c      = &Counter{hits: map[string]int{}}
+	calls  int
+)
+
+func init() { calls = 10 }
+
+func (c *Counter) Add(key string, n int) int {
+	calls++
+	c.hits[prefix+key] += n
+	return c.hits[prefix+key]
+}
+
+func Hit(key string, ns ...int) (int, int) {
+	var total int
+	for _, n := range ns {
+		total = c.Add(key, n)
+	}
+	return total, calls
+}
+
+func main() { panic("main must not run") }
+`)
+
+	concurrently(runners, func(k int) {
+		res, err := r.Call("Hit", map[string]interface{}{"prefix": fmt.Sprint(k)}, "key", 1, k, "2")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got := fmt.Sprint(res[0], res[1]); got != fmt.Sprint(k+3, 13) {
+			t.Errorf("call %d: got %s, want %d 13", k, got, k+3)
+		}
+	})
+
+	for _, test := range []struct {
+		name string
+		vars map[string]interface{}
+		args []interface{}
+		err  string
+	}{
+		{name: "Miss", err: "undefined function: Miss"},
+		{name: "Hit", err: "Hit: got 0 arguments, want 2"},
+		{name: "Hit", vars: map[string]interface{}{"none": 1}, args: []interface{}{"k"}, err: "undefined global variable: none"},
+		{name: "Hit", args: []interface{}{"k", []int{}}, err: "Hit: argument 1: unable to cast []int{} of type []int to int"},
+	} {
+		if _, err := r.Call(test.name, test.vars, test.args...); err == nil || err.Error() != test.err {
+			t.Errorf("got error %v, want %q", err, test.err)
+		}
+	}
+}
+
+func TestRunnerPanic(t *testing.T) {
+	i := interp.New(interp.Options{Stderr: io.Discard})
+	eval(t, i, `var d int`)
+	r := newRunner(t, i, `10 / d`)
+
+	concurrently(runners, func(k int) {
+		res, _, err := r.Run(map[string]interface{}{"d": k % 2})
+		switch {
+		case k%2 == 0:
+			var p interp.Panic
+			if err == nil || !errors.As(err, &p) {
+				t.Errorf("run %d: got error %v, want a panic", k, err)
+			}
+		case err != nil:
+			t.Errorf("run %d: %v", k, err)
+		case !reflect.DeepEqual(res.Interface(), 10):
+			t.Errorf("run %d: got %v, want 10", k, res)
+		}
+	})
+}
+
+func TestRunnerLanguage(t *testing.T) {
+	i := interp.New(interp.Options{Stderr: io.Discard})
+	if err := i.Use(stdlib.Symbols); err != nil {
+		t.Fatal(err)
+	}
+	r := newRunner(t, i, `package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+type Shape interface{ Area() float64 }
+
+type Rect struct{ W, H float64 }
+
+func (r Rect) Area() float64 { return r.W * r.H }
+
+type Point struct {
+	X int
+	Y int `+"`json:\"y\"`"+`
+}
+
+var (
+	n   int
+	out string
+)
+
+func adder() func(int) int {
+	sum := 0
+	return func(v int) int { sum += v; return sum }
+}
+
+func safeDiv(a, b int) (q int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return a / b, nil
+}
+
+func main() {
+	add := adder()
+	for j := 1; j <= n; j++ {
+		add(j)
+	}
+
+	results := make(chan int, n)
+	for j := 0; j < n; j++ {
+		go func(j int) { results <- j * j }(j)
+	}
+	var squares []int
+	for j := 0; j < n; j++ {
+		squares = append(squares, <-results)
+	}
+	sort.Ints(squares)
+
+	var s Shape = Rect{W: float64(n), H: 2}
+	_, err := safeDiv(n, 0)
+
+	src := map[string]interface{}{"X": "3", "y": n}
+	var p Point = src
+	var m map[string]interface{} = p
+
+	out = fmt.Sprint(add(0), squares[len(squares)-1], s.Area(), err, p, m["y"], strconv.Itoa(n)+"!")
+}
+`)
+
+	concurrently(runners, func(k int) {
+		n := k%5 + 1
+		_, globals, err := r.Run(map[string]interface{}{"n": n})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		want := fmt.Sprintf("%d %d %d runtime error: integer divide by zero {3 %d} %d%d!", n*(n+1)/2, (n-1)*(n-1), 2*n, n, n, n)
+		if got := globals["out"].String(); got != want {
+			t.Errorf("run %d: got %q, want %q", k, got, want)
+		}
+	})
+}
+
+func TestExprConcurrent(t *testing.T) {
+	i := interp.New(interp.Options{})
+	eval(t, i, `func fib(n int) int { if n < 2 { return n }; return fib(n-1) + fib(n-2) }`)
+	e, err := i.CompileExpr(`fib(n) + len(s)`, map[string]reflect.Type{"n": reflect.TypeOf(0), "s": reflect.TypeOf("")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fib := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+	concurrently(runners, func(k int) {
+		res, err := e.Eval(map[string]interface{}{"n": k % 10, "s": strings.Repeat("x", k)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got := res.Interface(); got != fib[k%10]+k {
+			t.Errorf("eval %d: got %v, want %d", k, got, fib[k%10]+k)
+		}
+	})
+}
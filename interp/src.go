@@ -144,6 +144,11 @@ func (interp *Interpreter) importSrc(rPath, importPath string, skipTest bool) (s
 	interp.frame.mutex.Unlock()
 	interp.mutex.Unlock()
 
+	if interp.implicit != nil {
+		// Analyze compiles the imported packages without running them.
+		return pkgName, nil
+	}
+
 	// Once all package sources have been parsed, execute entry points then init functions.
 	for _, n := range rootNodes {
 		if err = genRun(n); err != nil {
@@ -512,12 +512,13 @@ func nodeType2(interp *Interpreter, sc *scope, n *node, seen []*node) (t *itype,
 		if t, err = nodeType2(interp, sc, n.child[0], seen); err != nil {
 			return nil, err
 		}
+		operand := n.child[0]
 		// For operators other than shift, get the type from the 2nd operand if the first is untyped.
 		if t.untyped && !isShiftNode(n) {
 			var t1 *itype
 			t1, err = nodeType2(interp, sc, n.child[1], seen)
 			if !(t1.untyped && isInt(t1.TypeOf()) && isFloat(t.TypeOf())) {
-				t = t1
+				t, operand = t1, n.child[1]
 			}
 		}
 
@@ -541,6 +542,11 @@ func nodeType2(interp *Interpreter, sc *scope, n *node, seen []*node) (t *itype,
 			dt = sc.def.typ.ret[childPos(n)]
 		}
 
+		if !interp.strict && !operand.rval.IsValid() && isMismatchedType(t, dt) {
+			// In the loose dialect, operands of another type than the destination
+			// keep their type, and the result is converted at assignment.
+			dt = t
+		}
 		if isInterfaceSrc(dt) {
 			dt.val = t
 		}
@@ -817,6 +823,9 @@ func nodeType2(interp *Interpreter, sc *scope, n *node, seen []*node) (t *itype,
 		case binPkgT:
 			pkg := interp.binPkg[lt.path]
 			if v, ok := pkg[name]; ok {
+				if err = interp.checkSymbol(n, lt.path, name); err != nil {
+					break
+				}
 				rtype := v.Type()
 				if isBinType(v) {
 					// A bin type is encoded as a pointer on a typed nil value.
@@ -1237,7 +1246,7 @@ func (t *itype) assignableTo(o *itype) bool {
 	if con == nil || !isConstType(o) {
 		return false
 	}
-	return representableConst(con, o.TypeOf())
+	return representableConst(con, o.TypeOf(), n.interp.strict)
 }
 
 // convertibleTo returns true if t is convertible to o.
@@ -1654,10 +1663,21 @@ func exportName(s string) string {
 	return "X" + s
 }
 
+// sourceFieldName returns the name of field f of struct type t, as declared in
+// source. Fields of interpreted structs are exported by exportName, which
+// is reverted here.
+func sourceFieldName(t reflect.Type, f reflect.StructField) string {
+	if t.Name() == "" && len(f.Name) > 1 && f.Name[0] == 'X' && !canExport(f.Name[1:]) {
+		return f.Name[1:]
+	}
+	return f.Name
+}
+
 var (
 	// TODO(mpl): generators.
-	interf   = reflect.TypeOf((*interface{})(nil)).Elem()
-	constVal = reflect.TypeOf((*constant.Value)(nil)).Elem()
+	interf             = reflect.TypeOf((*interface{})(nil)).Elem()
+	constVal           = reflect.TypeOf((*constant.Value)(nil)).Elem()
+	valueInterfaceType = reflect.TypeOf((*valueInterface)(nil)).Elem()
 )
 
 type fieldRebuild struct {
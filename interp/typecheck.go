@@ -16,7 +16,9 @@ type opPredicates map[action]func(reflect.Type) bool
 // type system should used, namely reflect.Type with exception
 // of the untyped flag on itype.
 type typecheck struct {
-	scope *scope
+	scope  *scope
+	conv   *converter
+	strict bool // strict Go semantics, no implicit conversions
 }
 
 // op type checks an expression against a set of expression predicates.
@@ -38,6 +40,7 @@ func (check typecheck) assignment(n *node, typ *itype, context string) error {
 	if n.typ == nil {
 		return n.cfgErrorf("invalid type in %s", context)
 	}
+	ntyp := n.typ
 	if n.typ.untyped {
 		if typ == nil || isInterface(typ) {
 			if typ == nil && n.typ.cat == nilT {
@@ -54,15 +57,31 @@ func (check typecheck) assignment(n *node, typ *itype, context string) error {
 		return nil
 	}
 
-	if !canIconv(n.typ, typ) && typ.str != "*unsafe2.dummy" {
+	if !check.assignable(n.typ, typ) && typ.str != "*unsafe2.dummy" {
 		if context == "" {
 			return n.cfgErrorf("cannot use type %s as type %s", n.typ.id(), typ.id())
 		}
 		return n.cfgErrorf("cannot use type %s as type %s in %s", n.typ.id(), typ.id(), context)
 	}
+	if !check.strict && (!ntyp.assignableTo(typ) || isInterface(ntyp) && !isInterface(typ)) {
+		// An interface value assigned to a concrete type is asserted at run time.
+		if context == "" {
+			context = "argument"
+		}
+		n.interp.noteImplicit(n, context, ntyp.id(), typ.id())
+	}
 	return nil
 }
 
+// assignable returns true if a value of type t can be assigned to type o.
+// Outside of strict mode, implicitly convertible values are also accepted.
+func (check typecheck) assignable(t, o *itype) bool {
+	if check.strict {
+		return t.assignableTo(o)
+	}
+	return check.conv.canIconv(t, o)
+}
+
 // assignExpr type checks an assign expression.
 //
 // This is done per pair of assignments.
@@ -82,7 +101,11 @@ func (check typecheck) assignExpr(n, dest, src *node) error {
 		return n.cfgErrorf("assignment operation %s requires single-valued expressions", n.action)
 	}
 
-	return check.binaryExpr(n)
+	if err := check.binaryExpr(n); err != nil {
+		return err
+	}
+	check.noteOperator(n)
+	return nil
 }
 
 // addressExpr type checks a unary address expression.
@@ -116,6 +139,20 @@ func (check typecheck) addressExpr(n *node) error {
 	return nil
 }
 
+// sendStmt type checks a send statement.
+func (check typecheck) sendStmt(n *node) error {
+	c0, c1 := n.child[0], n.child[1]
+	typ := c0.typ.resolveAlias()
+	if typ.TypeOf().Kind() != reflect.Chan {
+		return n.cfgErrorf("invalid operation: cannot send to non-channel %s", c0.typ.id())
+	}
+	etyp := typ.val
+	if etyp == nil {
+		etyp = valueTOf(typ.TypeOf().Elem())
+	}
+	return check.assignment(c1, etyp, "send")
+}
+
 // starExpr type checks a star expression on a variable.
 func (check typecheck) starExpr(n *node) error {
 	if n.typ.TypeOf().Kind() != reflect.Ptr {
@@ -130,7 +167,7 @@ var unaryOpPredicates = opPredicates{
 	aPos:    isNumber,
 	aNeg:    isNumber,
 	aBitNot: isInt,
-	aNot:    canRconvBool,
+	aNot:    func(t reflect.Type) bool { return t != nil }, // All values have a truthiness, see truthy.
 }
 
 // unaryExpr type checks a unary expression.
@@ -151,6 +188,22 @@ func (check typecheck) unaryExpr(n *node) error {
 		return nil
 	}
 
+	if !check.strict && (n.action == aInc || n.action == aDec) && isDynamicIndex(c0) {
+		// The operand type is only known at runtime.
+		n.interp.noteImplicit(n, "operator "+n.action.String(), c0.typ.id(), c0.typ.id())
+		return nil
+	}
+
+	if !check.strict && t0.Kind() == reflect.Interface && (n.action == aNeg || n.action == aPos || n.action == aBitNot) {
+		// Dynamic operand, see the promotion rules of dynamic operators.
+		n.interp.noteImplicit(n, "operator "+n.action.String(), c0.typ.id(), c0.typ.id())
+		return nil
+	}
+
+	if check.strict && n.action == aNot {
+		return check.op(opPredicates{aNot: isBoolean}, n.action, n, c0, t0)
+	}
+
 	return check.op(unaryOpPredicates, n.action, n, c0, t0)
 }
 
@@ -165,6 +218,11 @@ func (check typecheck) shift(n *node) error {
 		c0.rval = reflect.ValueOf(v0)
 	}
 
+	if !check.strict && (isInterface(c0.typ) || isInterface(c1.typ)) {
+		// Dynamic operands, see the promotion rules of dynamic operators.
+		return nil
+	}
+
 	if !(c0.typ.untyped && v0 != nil && v0.Kind() == constant.Int || isInt(t0)) {
 		return n.cfgErrorf("invalid operation: shift of type %v", c0.typ.id())
 	}
@@ -182,10 +240,37 @@ func (check typecheck) shift(n *node) error {
 	return nil
 }
 
+// noteOperator records, while analyzing, the binary operation or assignment
+// operation n if the loose dialect resolves it on interface or mismatched
+// operands. The result type of n must be known.
+func (check typecheck) noteOperator(n *node) {
+	c0, c1 := n.child[0], n.child[1]
+	if check.strict || n.interp.implicit == nil || c0.typ == nil || c1.typ == nil || c0.typ.isNil() || c1.typ.isNil() {
+		return
+	}
+	dynamic := isInterface(c0.typ) || isInterface(c1.typ)
+	a := n.action
+	if isAssignAction(a) {
+		a--
+	}
+	if !dynamic && (isShiftAction(a) || c0.typ.equals(c1.typ)) {
+		return
+	}
+	target := n.typ
+	if isAssignAction(n.action) {
+		target = c0.typ
+	}
+	n.interp.noteImplicit(n, "operator "+a.String(), c0.typ.id()+", "+c1.typ.id(), target.id())
+}
+
 // comparison type checks a comparison binary expression.
 func (check typecheck) comparison(n *node) error {
 	c0, c1 := n.child[0], n.child[1]
 
+	if check.strict {
+		return check.strictComparison(n)
+	}
+
 	if !(c0.typ.isNil() || c1.typ.isNil()) && !c0.typ.assignableTo(c1.typ) && !c1.typ.assignableTo(c0.typ) &&
 		!((isNumber(c0.typ.rtype) || isString(c0.typ.rtype) || isBoolean(c0.typ.rtype)) || isConstantValue(c0.typ.rtype) &&
 			(isNumber(c1.typ.rtype) || isString(c1.typ.rtype)) || isBoolean(c0.typ.rtype) || isConstantValue(c0.typ.rtype)) {
@@ -211,6 +296,31 @@ func (check typecheck) comparison(n *node) error {
 	return nil
 }
 
+// strictComparison type checks a comparison binary expression following Go rules.
+func (check typecheck) strictComparison(n *node) error {
+	c0, c1 := n.child[0], n.child[1]
+
+	if !c0.typ.assignableTo(c1.typ) && !c1.typ.assignableTo(c0.typ) {
+		return n.cfgErrorf("invalid operation: mismatched types %s and %s", c0.typ.id(), c1.typ.id())
+	}
+
+	ok := false
+	switch n.action {
+	case aEqual, aNotEqual:
+		ok = c0.typ.comparable() && c1.typ.comparable() || c0.typ.isNil() && c1.typ.hasNil() || c1.typ.isNil() && c0.typ.hasNil()
+	case aLower, aLowerEqual, aGreater, aGreaterEqual:
+		ok = c0.typ.ordered() && c1.typ.ordered()
+	}
+	if !ok {
+		typ := c0.typ
+		if typ.isNil() {
+			typ = c1.typ
+		}
+		return n.cfgErrorf("invalid operation: operator %v not defined on %s", n.action, typ.id())
+	}
+	return nil
+}
+
 var binaryOpPredicates = opPredicates{
 	aAdd: func(typ reflect.Type) bool { return isNumber(typ) || isString(typ) },
 	aSub: isNumber,
@@ -246,9 +356,14 @@ func (check typecheck) binaryExpr(n *node) error {
 
 	switch n.action {
 	case aAdd:
-		if n.typ == nil {
+		if n.typ == nil || !check.strict {
 			break
 		}
+		// Catch mixing string and number for "+" operator use.
+		k, k0, k1 := isNumber(n.typ.TypeOf()), isNumber(c0.typ.TypeOf()), isNumber(c1.typ.TypeOf())
+		if k != k0 || k != k1 {
+			return n.cfgErrorf("cannot use type %s as type %s in assignment", c0.typ.id(), n.typ.id())
+		}
 	case aRem:
 		if zeroConst(c1) {
 			return n.cfgErrorf("invalid operation: division by zero")
@@ -270,11 +385,21 @@ func (check typecheck) binaryExpr(n *node) error {
 		return check.comparison(n)
 	}
 
-	return nil
+	if !check.strict {
+		return nil
+	}
+
+	if !c0.typ.equals(c1.typ) {
+		return n.cfgErrorf("invalid operation: mismatched types %s and %s", c0.typ.id(), c1.typ.id())
+	}
+
+	t0 := c0.typ.TypeOf()
+
+	return check.op(binaryOpPredicates, a, n, c0, t0)
 }
 
 func zeroConst(n *node) bool {
-	return n.typ.untyped && constant.Sign(rconvConstNumber(n.rval.Interface().(constant.Value))) == 0
+	return n.typ.untyped && constant.Sign(n.interp.conv.rconvConstNumber(n.rval.Interface().(constant.Value))) == 0
 }
 
 func (check typecheck) index(n *node, max int) error {
@@ -643,7 +768,7 @@ func (check typecheck) conversion(n *node, typ *itype) error {
 	switch {
 	case c != nil && isConstType(typ):
 		switch t := typ.TypeOf(); {
-		case representableConst(c, t):
+		case representableConst(c, t, check.strict):
 			ok = true
 		case isInt(n.typ.TypeOf()) && isString(t):
 			codepoint := int64(-1)
@@ -748,7 +873,7 @@ func (check typecheck) builtin(name string, n *node, child []*node, ellipsis boo
 	case bltnAppend:
 		typ := params[0].Type()
 		t := typ.TypeOf()
-		if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Interface) {
+		if t == nil || (t.Kind() != reflect.Slice && (check.strict || t.Kind() != reflect.Interface)) {
 			return params[0].nod.cfgErrorf("first argument to append must be slice; have %s", typ.id())
 		}
 		if t.Kind() == reflect.Interface {
@@ -783,8 +908,10 @@ func (check typecheck) builtin(name string, n *node, child []*node, ellipsis boo
 		typ := arrayDeref(params[0].Type())
 		ok := false
 		switch typ.TypeOf().Kind() {
-		case reflect.Array, reflect.Slice, reflect.Chan, reflect.Interface:
+		case reflect.Array, reflect.Slice, reflect.Chan:
 			ok = true
+		case reflect.Interface:
+			ok = !check.strict
 		case reflect.String, reflect.Map:
 			ok = name == bltnLen
 		}
@@ -864,11 +991,15 @@ func (check typecheck) builtin(name string, n *node, child []*node, ellipsis boo
 		}
 	case bltnDelete:
 		typ := params[0].Type()
+		if !check.strict && typ.TypeOf().Kind() == reflect.Interface {
+			// The map is only known at runtime.
+			break
+		}
 		if typ.TypeOf().Kind() != reflect.Map {
 			return params[0].nod.cfgErrorf("first argument to delete must be map; have %s", typ.id())
 		}
 		ktyp := params[1].Type()
-		if typ.key != nil && !ktyp.assignableTo(typ.key) {
+		if typ.key != nil && !check.assignable(ktyp, typ.key) {
 			return params[1].nod.cfgErrorf("cannot use %s as type %s in delete", ktyp.id(), typ.key.id())
 		}
 	case bltnMake:
@@ -1064,6 +1195,10 @@ func (check typecheck) convertUntyped(n *node, typ *itype) error {
 			n.typ = typ
 		}
 		return nil
+	case !check.strict && isString(ntyp) && check.conv.coercion(ntyp, ttyp) != nil:
+		// The string constant is coerced at run time, e.g. to a time.Duration.
+		ityp = n.typ.defaultType(n.rval, check.scope)
+		rtyp = ityp.TypeOf()
 	case isNumber(ttyp) || isString(ttyp) || isBoolean(ttyp):
 		ityp = typ
 		rtyp = ttyp
@@ -1076,6 +1211,10 @@ func (check typecheck) convertUntyped(n *node, typ *itype) error {
 		}
 		ityp = n.typ.defaultType(n.rval, check.scope)
 		rtyp = ntyp
+	case !check.strict && check.conv.lookup(ntyp, ttyp) != nil:
+		// The constant is converted at run time by a registered converter.
+		ityp = n.typ.defaultType(n.rval, check.scope)
+		rtyp = ityp.TypeOf()
 	case isArray(typ) || isMap(typ) || isChan(typ) || isFunc(typ) || isPtr(typ):
 		// TODO(nick): above we are acting on itype, but really it is an rtype check. This is not clear which type
 		// 		 	   plain we are in. Fix this later.
@@ -1115,7 +1254,7 @@ func (check typecheck) representable(n *node, t reflect.Type) error {
 		return nil
 	}
 
-	if !representableConst(c, t) {
+	if !representableConst(c, t, check.strict) {
 		typ := n.typ.TypeOf()
 		if isNumber(typ) && isNumber(t) {
 			// numeric conversion : error msg
@@ -1146,26 +1285,63 @@ func (check typecheck) convertConst(v reflect.Value, t reflect.Type) (reflect.Va
 		return v, nil
 	}
 
+	if check.strict {
+		return convertConstStrict(c, v, t)
+	}
+
 	kind := c.Kind()
 	switch kind {
 	case constant.Bool:
 		v = reflect.ValueOf(constant.BoolVal(c))
-		return rconv(v, t)
+		return check.conv.rconv(v, t)
 	case constant.String:
 		v = reflect.ValueOf(constant.StringVal(c))
-		return rconv(v, t)
+		return check.conv.rconv(v, t)
 	case constant.Int:
 		val, _ := constant.Int64Val(c)
 		v = reflect.ValueOf(val)
-		return rconv(v, t)
+		return check.conv.rconv(v, t)
 	case constant.Float:
 		val, _ := constant.Float64Val(c)
 		v = reflect.ValueOf(val)
-		return rconv(v, t)
+		return check.conv.rconv(v, t)
 	case constant.Complex:
 		r, _ := constant.Float32Val(constant.Real(c))
 		i, _ := constant.Float32Val(constant.Imag(c))
-		return rconv(reflect.ValueOf(complex(r, i)), t)
+		return check.conv.rconv(reflect.ValueOf(complex(r, i)), t)
+	default:
+		return v, errCantConvert
+	}
+}
+
+// convertConstStrict converts the constant c to type t following Go rules.
+func convertConstStrict(c constant.Value, v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	kind := t.Kind()
+	switch kind {
+	case reflect.Bool:
+		v = reflect.ValueOf(constant.BoolVal(c))
+	case reflect.String:
+		v = reflect.ValueOf(constant.StringVal(c))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, _ := constant.Int64Val(constant.ToInt(c))
+		v = reflect.ValueOf(i).Convert(t)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, _ := constant.Uint64Val(constant.ToInt(c))
+		v = reflect.ValueOf(i).Convert(t)
+	case reflect.Float32:
+		f, _ := constant.Float32Val(constant.ToFloat(c))
+		v = reflect.ValueOf(f)
+	case reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(c))
+		v = reflect.ValueOf(f)
+	case reflect.Complex64:
+		r, _ := constant.Float32Val(constant.Real(c))
+		i, _ := constant.Float32Val(constant.Imag(c))
+		v = reflect.ValueOf(complex(r, i)).Convert(t)
+	case reflect.Complex128:
+		r, _ := constant.Float64Val(constant.Real(c))
+		i, _ := constant.Float64Val(constant.Imag(c))
+		v = reflect.ValueOf(complex(r, i)).Convert(t)
 	default:
 		return v, errCantConvert
 	}
@@ -1186,12 +1362,46 @@ var bitlen = [...]int{
 	reflect.Uintptr: 64,
 }
 
-func representableConst(c constant.Value, t reflect.Type) bool {
+// representableConst returns true if the constant c is representable by a value
+// of type t. Outside of strict mode, numeric, string and boolean types can hold any
+// constant, which is converted at run time.
+func representableConst(c constant.Value, t reflect.Type, strict bool) bool {
 	switch {
-	case isInt(t):
+	case !strict && (isInt(t) || isFloat(t) || isString(t) || isBoolean(t)):
 		return true
+	case isInt(t):
+		x := constant.ToInt(c)
+		if x.Kind() != constant.Int {
+			return false
+		}
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if _, ok := constant.Int64Val(x); !ok {
+				return false
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if _, ok := constant.Uint64Val(x); !ok {
+				return false
+			}
+		default:
+			return false
+		}
+		return constant.BitLen(x) <= bitlen[t.Kind()]
 	case isFloat(t):
-		return true
+		x := constant.ToFloat(c)
+		if x.Kind() != constant.Float {
+			return false
+		}
+		switch t.Kind() {
+		case reflect.Float32:
+			f, _ := constant.Float32Val(x)
+			return !math.IsInf(float64(f), 0)
+		case reflect.Float64:
+			f, _ := constant.Float64Val(x)
+			return !math.IsInf(f, 0)
+		default:
+			return false
+		}
 	case isComplex(t):
 		x := constant.ToComplex(c)
 		if x.Kind() != constant.Complex {
@@ -1210,9 +1420,9 @@ func representableConst(c constant.Value, t reflect.Type) bool {
 			return false
 		}
 	case isString(t):
-		return true
+		return c.Kind() == constant.String
 	case isBoolean(t):
-		return true
+		return c.Kind() == constant.Bool
 	default:
 		return false
 	}
@@ -5,6 +5,7 @@ import (
 	"go/constant"
 	"go/token"
 	"reflect"
+	"sync"
 )
 
 const (
@@ -176,11 +177,7 @@ func genValueAs(n *node, t reflect.Type) func(*frame) reflect.Value {
 				return reflect.New(t).Elem()
 			}
 		}
-		vc, err := rconv(v, t)
-		if err != nil {
-			panic(n.runErrorf("failed to convert %s to %s", v.Type(), t))
-		}
-		return vc
+		return n.rconv(v, t)
 	}
 }
 
@@ -295,6 +292,65 @@ func genDestValue(typ *itype, n *node) func(*frame) reflect.Value {
 	return genValue(n)
 }
 
+// genDestValueImplicit is genDestValue, with the value of n implicitly
+// converted to typ if necessary, see needsImplicitConv.
+func genDestValueImplicit(typ *itype, n *node) func(*frame) reflect.Value {
+	if needsImplicitConv(n, typ) {
+		return genValueImplicit(n, typ.frameType())
+	}
+	return genDestValue(typ, n)
+}
+
+// needsImplicitConv returns true if the value of n must be converted at run
+// time to be stored in a destination of type typ. Outside of strict mode, a
+// value of a type not assignable to the destination type, such as an
+// interface value assigned to a concrete type, is implicitly converted.
+func needsImplicitConv(n *node, typ *itype) bool {
+	if n.interp.strict || n.rval.IsValid() || n.typ.untyped || n.typ.cat == nilT || isInterface(typ) || isFuncSrc(typ) || isFuncSrc(n.typ) {
+		return false
+	}
+	st, dt := n.typ.frameType(), typ.frameType()
+	return st != nil && dt != nil && !st.AssignableTo(dt)
+}
+
+// genValueMapKey returns a generator of the value of k, used as a key of map
+// m. Outside of strict mode, the key is implicitly converted to the key type
+// of the map.
+func genValueMapKey(m, k *node) func(*frame) reflect.Value {
+	if m.interp.strict {
+		return genValue(k)
+	}
+	ktyp := mapKeyType(m.typ)
+	convertLiteralValue(k, ktyp.TypeOf())
+	if needsImplicitConv(k, ktyp) {
+		return genValueImplicit(k, ktyp.frameType())
+	}
+	return genValue(k)
+}
+
+// mapKeyType returns the key type of map type t.
+func mapKeyType(t *itype) *itype {
+	if t = t.resolveAlias(); t.key != nil {
+		return t.key
+	}
+	return valueTOf(t.TypeOf().Key())
+}
+
+// genValueImplicit returns a generator of the value of n, implicitly
+// converted to type t according to the conversion and failure policies
+// of the interpreter.
+func genValueImplicit(n *node, t reflect.Type) func(*frame) reflect.Value {
+	value := genValue(n)
+
+	return func(f *frame) reflect.Value {
+		v := value(f)
+		if v.Type().AssignableTo(t) {
+			return v
+		}
+		return n.rconv(v, t)
+	}
+}
+
 func genValueArray(n *node) func(*frame) reflect.Value {
 	value := genValue(n)
 	// dereference array pointer, to support array operations on array pointer
@@ -562,14 +618,15 @@ func vFloat(v reflect.Value) (i float64) {
 }
 
 func vString(v reflect.Value) (s string) {
+	var conv *converter // default conversion rules
 	if c := vConstantValue(v); c != nil {
 		if c.Kind() != constant.Unknown && c.Kind() != constant.String {
-			c = rconvConstString(c)
+			c = conv.rconvConstString(c)
 		}
 		s = constant.StringVal(c)
 		return s
 	}
-	v, _ = rconv(v, reflect.TypeOf(""))
+	v, _ = conv.rconv(v, reflect.TypeOf(""))
 	return v.String()
 }
 
@@ -585,26 +642,19 @@ func genValueInt(n *node) func(*frame) (reflect.Value, int64) {
 
 	switch n.typ.TypeOf().Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, rconvToInt64(v) }
+		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, n.interp.conv.rconvToInt64(v) }
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, rconvToInt64(v) }
+		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, n.interp.conv.rconvToInt64(v) }
 	case reflect.Float32, reflect.Float64:
-		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, rconvToInt64(v) }
+		return func(f *frame) (reflect.Value, int64) { v := value(f); return v, n.interp.conv.rconvToInt64(v) }
 	case reflect.Complex64, reflect.Complex128:
 		if n.typ.untyped && n.rval.IsValid() && imag(n.rval.Complex()) == 0 {
-			return func(f *frame) (reflect.Value, int64) { v := value(f); return v, rconvToInt64(v) }
+			return func(f *frame) (reflect.Value, int64) { v := value(f); return v, n.interp.conv.rconvToInt64(v) }
 		}
 	case reflect.Interface, reflect.Bool:
 		return func(f *frame) (reflect.Value, int64) {
-			v := value(f)
-			t := v.Type()
-			var err error
-			typeInt64 := reflect.TypeOf(int64(0))
-			v, err = rconv(v, typeInt64)
-			if err != nil {
-				panic(n.runErrorf("failed to convert %s to %s", t, typeInt64))
-			}
-			return v, rconvToInt64(v)
+			v := n.rconv(value(f), reflect.TypeOf(int64(0)))
+			return v, n.interp.conv.rconvToInt64(v)
 		}
 	}
 	return nil
@@ -615,44 +665,103 @@ func genValueUint(n *node) func(*frame) (reflect.Value, uint64) {
 
 	switch n.typ.TypeOf().Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, rconvToUint64(v) }
+		return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, n.interp.conv.rconvToUint64(v) }
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, v.Uint() }
 	case reflect.Float32, reflect.Float64:
-		return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, rconvToUint64(v) }
+		return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, n.interp.conv.rconvToUint64(v) }
 	case reflect.Complex64, reflect.Complex128:
 		if n.typ.untyped && n.rval.IsValid() && imag(n.rval.Complex()) == 0 {
-			return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, rconvToUint64(v) }
+			return func(f *frame) (reflect.Value, uint64) { v := value(f); return v, n.interp.conv.rconvToUint64(v) }
 		}
 	case reflect.Interface, reflect.Bool:
 		return func(f *frame) (reflect.Value, uint64) {
-			v := value(f)
-			t := v.Type()
-			var err error
-			typeInt64 := reflect.TypeOf(int64(0))
-			v, err = rconv(v, typeInt64)
-			if err != nil {
-				panic(n.runErrorf("failed to convert %s to %s", t, typeInt64))
-			}
-			return v, rconvToUint64(v)
+			v := n.rconv(value(f), reflect.TypeOf(int64(0)))
+			return v, n.interp.conv.rconvToUint64(v)
 		}
 	}
 	return nil
 }
 
+// genValueTruthy returns a function evaluating the truthiness of the value of
+// n, as produced by value. The truthiness is defined by the Truthy method of an
+// interpreted type, or by converter.truthy otherwise.
+func genValueTruthy(n *node, value func(*frame) reflect.Value) func(*frame) bool {
+	if isBool(n.typ) {
+		return func(f *frame) bool { return value(f).Bool() }
+	}
+	if !isInterface(n.typ) {
+		if m, index := n.typ.lookupMethod("Truthy"); isTruthyMethod(m) {
+			call := genTruthyCall(m, index)
+			return func(f *frame) bool { return call(f, value(f)) }
+		}
+	}
+	var calls sync.Map // *itype -> func(*frame, reflect.Value) bool, or nil
+	return func(f *frame) bool {
+		v := value(f)
+		if v.IsValid() && v.CanInterface() {
+			if vi, ok := v.Interface().(valueInterface); ok && vi.node != nil {
+				call, ok := calls.Load(vi.node.typ)
+				if !ok {
+					var c func(*frame, reflect.Value) bool
+					if m, index := vi.node.typ.lookupMethod("Truthy"); isTruthyMethod(m) {
+						c = genTruthyCall(m, index)
+					}
+					call, _ = calls.LoadOrStore(vi.node.typ, c)
+				}
+				if c := call.(func(*frame, reflect.Value) bool); c != nil {
+					return c(f, concreteValue(vi.value))
+				}
+			}
+		}
+		return n.interp.conv.truthy(v)
+	}
+}
+
+// isTruthyMethod returns true if m is a method definition matching Truthy.
+func isTruthyMethod(m *node) bool {
+	return m != nil && m.typ != nil && len(m.typ.arg) == 0 && len(m.typ.ret) == 1 && isBool(m.typ.ret[0])
+}
+
+// genTruthyCall returns a function calling the interpreted Truthy method m on
+// a receiver, or on its embedded field at index. A nil pointer receiver is
+// false.
+func genTruthyCall(m *node, index []int) func(*frame, reflect.Value) bool {
+	call := genFunctionCall(m, m)
+
+	return func(f *frame, v reflect.Value) bool {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return false
+		}
+		if len(index) > 0 {
+			if v.Kind() == reflect.Ptr {
+				v = v.Elem()
+			}
+			v = v.FieldByIndex(index)
+		}
+		if !v.CanAddr() {
+			// Make the receiver addressable for a pointer receiver.
+			a := reflect.New(v.Type()).Elem()
+			a.Set(v)
+			v = a
+		}
+		return call(f, v, nil)[0].Bool()
+	}
+}
+
 func genValueFloat(n *node) func(*frame) (reflect.Value, float64) {
 	value := genValue(n)
 
 	switch n.typ.TypeOf().Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, rconvToFloat64(v) }
+		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, n.interp.conv.rconvToFloat64(v) }
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, rconvToFloat64(v) }
+		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, n.interp.conv.rconvToFloat64(v) }
 	case reflect.Float32, reflect.Float64:
-		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, rconvToFloat64(v) }
+		return func(f *frame) (reflect.Value, float64) { v := value(f); return v, n.interp.conv.rconvToFloat64(v) }
 	case reflect.Complex64, reflect.Complex128:
 		if n.typ.untyped && n.rval.IsValid() && imag(n.rval.Complex()) == 0 {
-			return func(f *frame) (reflect.Value, float64) { v := value(f); return v, rconvToFloat64(v) }
+			return func(f *frame) (reflect.Value, float64) { v := value(f); return v, n.interp.conv.rconvToFloat64(v) }
 		}
 	}
 	return nil